@@ -1,3 +1,90 @@
+# server info
+data "mongodb_server_info" "current" {}
+
+# database
+resource "mongodb_database" "example_database" {
+  name = var.database_name
+
+  # Refuse to drop the database while it still contains documents
+  prevent_destroy_if_not_empty = true
+}
+
+# collection
+resource "mongodb_collection" "example_collection" {
+  database = mongodb_database.example_database.name
+  name     = var.collection_name
+
+  change_stream_pre_and_post_images = true
+
+  validator = jsonencode({
+    "$jsonSchema" = {
+      bsonType = "object"
+      required = ["name"]
+      properties = {
+        name = { bsonType = "string" }
+      }
+    }
+  })
+  validation_level  = "moderate"
+  validation_action = "error"
+}
+
+# time series collection, only created on servers which support it
+resource "mongodb_collection" "example_time_series" {
+  count = tonumber(split(".", data.mongodb_server_info.current.version)[0]) >= 5 ? 1 : 0
+
+  database = mongodb_database.example_database.name
+  name     = "${var.collection_name}_metrics"
+
+  time_series = {
+    time_field  = "timestamp"
+    meta_field  = "metadata"
+    granularity = "minutes"
+  }
+  expire_after_seconds = 86400
+}
+
+# view
+resource "mongodb_view" "example_view" {
+  database = mongodb_database.example_database.name
+  name     = "${var.collection_name}_names"
+  view_on  = mongodb_collection.example_collection.name
+  pipeline = jsonencode([
+    { "$project" = { _id = 0, name = 1 } }
+  ])
+}
+
+# read-only access to the view
+resource "mongodb_role" "example_view_reader" {
+  name     = "${var.role_name}_view_reader"
+  database = var.database_name
+  privileges = [
+    {
+      actions = ["find"]
+      resource = {
+        collection = mongodb_view.example_view.name
+        db         = mongodb_view.example_view.database
+      }
+    }
+  ]
+}
+
+# monitoring role, cluster-wide privileges can only be granted in admin
+resource "mongodb_role" "example_monitoring" {
+  name     = "${var.role_name}_monitoring"
+  database = "admin"
+  privileges = [
+    {
+      actions  = ["serverStatus", "replSetGetStatus"]
+      resource = { cluster = true }
+    },
+    {
+      actions  = ["collStats", "dbStats", "indexStats"]
+      resource = { any_resource = true }
+    }
+  ]
+}
+
 # role
 resource "mongodb_role" "example_role" {
   name     = var.role_name
@@ -33,15 +120,40 @@ resource "mongodb_user" "example_role_user" {
     }
   ]
 
+  custom_data = jsonencode({
+    team   = "platform"
+    ticket = "OPS-123"
+  })
+
+  # only allow connections from the private network
+  authentication_restrictions = [
+    {
+      client_source = ["10.0.0.0/8", "127.0.0.1"]
+    }
+  ]
+
   depends_on = [mongodb_role.example_role]
 }
 
 
+# X.509 user, authenticated by the certificate subject
+resource "mongodb_user" "example_x509_user" {
+  username = "CN=${var.user_username},OU=terraform,O=example.com"
+  database = "$external"
+
+  roles = [
+    {
+      role = mongodb_role.example_view_reader.name
+      db   = mongodb_role.example_view_reader.database
+    }
+  ]
+}
+
 # index example
 # Generic index resource that can be reused
 resource "mongodb_index" "example_index" {
-  database   = var.database_name
-  collection = var.collection_name
+  database   = mongodb_collection.example_collection.database
+  collection = mongodb_collection.example_collection.name
   name       = var.index_name
   keys       = var.index_keys
   
@@ -59,4 +171,35 @@ resource "mongodb_index" "example_index" {
   bits                     = var.bits
   min                      = var.min
   max                      = var.max
-}
\ No newline at end of file
+
+  # Builds on large collections can take longer than the default hour
+  timeouts {
+    create = "3h"
+  }
+}
+
+# user read back from the server, e.g. to check its effective roles
+data "mongodb_user" "example_existing_user" {
+  username = mongodb_user.example_role_user.username
+  database = mongodb_user.example_role_user.database
+}
+
+# users granted root directly, e.g. to audit them
+data "mongodb_users" "root_users" {
+  role = {
+    role = "root"
+    db   = "admin"
+  }
+}
+
+# built-in role with everything it effectively grants
+data "mongodb_role" "read_write" {
+  name     = "readWrite"
+  database = mongodb_database.example_database.name
+}
+
+# custom and built-in roles of the database
+data "mongodb_roles" "example_database_roles" {
+  database           = mongodb_database.example_database.name
+  show_builtin_roles = true
+}
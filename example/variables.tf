@@ -73,8 +73,11 @@ variable "index_name" {
 }
 
 variable "index_keys" {
-  description = "Index keys configuration as key-value map (field = index_type)"
-  type        = map(string)
+  description = "Ordered index keys configuration (field and direction or index type)"
+  type = list(object({
+    field     = string
+    direction = string
+  }))
 }
 
 variable "index_unique" {
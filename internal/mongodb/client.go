@@ -2,14 +2,20 @@ package mongodb
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"errors"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	mongooptions "go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+const (
+	adminDatabase = "admin"
+)
+
 type ClientOptions struct {
 	ConnectionString   string
 	Hosts              []string
@@ -22,15 +28,46 @@ type ClientOptions struct {
 	InsecureSkipVerify bool
 	Certificate        string
 	DirectConnection   bool
+
+	// ClientCertificate and ClientKey are PEM strings used for mutual TLS and MONGODB-X509 authentication
+	ClientCertificate   string
+	ClientKey           string
+	ClientKeyPassphrase string
+
+	// SSHTunnel makes all connections through a jump host
+	SSHTunnel *SSHTunnelOptions
+	// Zero values keep driver defaults
+	ServerSelectionTimeout time.Duration
+	ConnectTimeout         time.Duration
+	// SocketTimeout limits each operation, the driver doesn't have a separate socket timeout anymore.
+	// It's ignored for operations whose context already has a deadline.
+	SocketTimeout time.Duration
+	MaxPoolSize   uint64
+	AppName       string
+	Compressors   []string
+	// MaxRetries limits how many times idempotent operations are retried after transient errors
+	MaxRetries int
+
+	// Proxy makes all connections through a SOCKS5 proxy, it's merged with proxy options of the connection string
+	Proxy *ProxyOptions
 }
 
 type Client struct {
 	ClientOptions
 
 	mongo *mongo.Client
+	// hosts are the seed list after the connection string is applied, they identify managed services
+	hosts  []string
+	server *ServerInfo
+
+	// connectMu guards connected and server, only a successful connection is remembered
+	connectMu sync.Mutex
+	connected bool
 }
 
-func New(ctx context.Context, options *ClientOptions) (*Client, error) {
+// New validates options and creates a client. The driver connects in the background,
+// so the server doesn't have to be reachable until Connect is called.
+func New(options *ClientOptions) (*Client, error) {
 	opt := mongooptions.Client().
 		SetHosts(options.Hosts).
 		SetAuth(mongooptions.Credential{
@@ -42,43 +79,138 @@ func New(ctx context.Context, options *ClientOptions) (*Client, error) {
 		SetReplicaSet(options.ReplicaSet).
 		SetDirect(options.DirectConnection)
 
-	if options.ConnectionString != "" {
-		opt.ApplyURI(options.ConnectionString)
+	if options.ServerSelectionTimeout > 0 {
+		opt.SetServerSelectionTimeout(options.ServerSelectionTimeout)
 	}
 
-	if options.TLS {
-		tlsConfig := &tls.Config{
-			InsecureSkipVerify: options.InsecureSkipVerify,
-		}
+	if options.ConnectTimeout > 0 {
+		opt.SetConnectTimeout(options.ConnectTimeout)
+	}
+
+	if options.SocketTimeout > 0 {
+		opt.SetTimeout(options.SocketTimeout)
+	}
 
-		if options.Certificate != "" {
-			certPool := x509.NewCertPool()
+	if options.MaxPoolSize > 0 {
+		opt.SetMaxPoolSize(options.MaxPoolSize)
+	}
 
-			ok := certPool.AppendCertsFromPEM([]byte(options.Certificate))
-			if !ok {
-				return nil, errors.New("failed to parse certificate")
-			}
+	if options.AppName != "" {
+		opt.SetAppName(options.AppName)
+	}
+
+	if len(options.Compressors) > 0 {
+		opt.SetCompressors(options.Compressors)
+	}
 
-			tlsConfig.RootCAs = certPool
+	if options.ConnectionString != "" {
+		opt.ApplyURI(options.ConnectionString)
+	}
+
+	// Client certificate can't be used without TLS
+	if options.TLS || options.ClientCertificate != "" {
+		tlsConfig, err := newTLSConfig(options)
+		if err != nil {
+			return nil, err
 		}
 
 		opt.SetTLSConfig(tlsConfig)
 	}
 
-	mongoClient, err := mongo.Connect(opt)
+	proxyOptions, err := proxyFromConnectionString(options.ConnectionString)
 	if err != nil {
 		return nil, err
 	}
 
-	err = mongoClient.Ping(ctx, nil)
+	proxyOptions, err = mergeProxyOptions(options.Proxy, proxyOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case options.SSHTunnel != nil && proxyOptions != nil:
+		return nil, errors.New("ssh tunnel and proxy can't be used together")
+	case options.SSHTunnel != nil:
+		dialer, err := newSSHDialer(options.SSHTunnel)
+		if err != nil {
+			return nil, err
+		}
+
+		opt.SetDialer(dialer)
+	case proxyOptions != nil:
+		dialer, err := newProxyDialer(proxyOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		opt.SetDialer(dialer)
+	}
+
+	mongoClient, err := mongo.Connect(opt)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &Client{
 		mongo:         mongoClient,
+		hosts:         opt.Hosts,
 		ClientOptions: *options,
 	}
 
 	return client, nil
 }
+
+// Connect checks the connection until it succeeds once. Failures aren't remembered,
+// so a server which was unreachable for a moment, e.g. during an election, is tried again by later callers.
+// The server is detected here rather than in New, which doesn't wait for the server.
+func (c *Client) Connect(ctx context.Context) error {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	if c.connected {
+		return nil
+	}
+
+	_, err := withRetry(ctx, c, "Ping", func() (struct{}, error) {
+		return struct{}{}, c.mongo.Ping(ctx, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	server, err := c.detectServer(ctx)
+	if err != nil {
+		// Detection is only used for plan checks, the server rejects unsupported options anyway
+		tflog.Warn(ctx, "error detecting MongoDB server", map[string]any{
+			"err": err,
+		})
+	}
+
+	c.server = server
+	c.connected = true
+
+	return nil
+}
+
+// runCommand runs a command which only reports its status.
+func (c *Client) runCommand(ctx context.Context, database string, command bson.D) error {
+	response := c.mongo.Database(database).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return commandError(command[0].Key, err)
+	}
+
+	var result Result
+
+	err = response.Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if result.Ok != 1 {
+		return FailedCommandError{Cmd: command[0].Key}
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	createCollectionCmd = "create"
+	modifyCollectionCmd = "collMod"
+	listCollectionsCmd  = "listCollections"
+	dropCollectionCmd   = "drop"
+
+	expirationOff = "off"
+)
+
+// setCollectionOptions is a workaround to use pointers, same as setIndexOptions.
+func setCollectionOptions(collection *Collection) func(*options.CreateCollectionOptions) error {
+	return func(opts *options.CreateCollectionOptions) error {
+		opts.Capped = collection.Options.Capped
+		opts.SizeInBytes = collection.Options.Size
+		opts.MaxDocuments = collection.Options.Max
+		opts.ExpireAfterSeconds = collection.Options.ExpireAfterSeconds
+		opts.Collation = collection.Options.Collation
+		opts.ValidationLevel = collection.Options.ValidationLevel
+		opts.ValidationAction = collection.Options.ValidationAction
+
+		if len(collection.Options.Validator) > 0 {
+			opts.Validator = collection.Options.Validator
+		}
+
+		if collection.Options.ClusteredIndex.IsSet() {
+			clusteredIndex := bson.D{
+				{Key: "key", Value: collection.Options.ClusteredIndex.Key},
+				{Key: "unique", Value: collection.Options.ClusteredIndex.Unique},
+			}
+
+			if collection.Options.ClusteredIndex.Name != nil {
+				clusteredIndex = append(clusteredIndex, bson.E{
+					Key:   "name",
+					Value: *collection.Options.ClusteredIndex.Name,
+				})
+			}
+
+			opts.ClusteredIndex = clusteredIndex
+		}
+
+		if collection.Options.TimeSeries != nil {
+			timeSeries := options.TimeSeries().
+				SetTimeField(collection.Options.TimeSeries.TimeField)
+
+			if collection.Options.TimeSeries.MetaField != nil {
+				timeSeries.SetMetaField(*collection.Options.TimeSeries.MetaField)
+			}
+
+			if collection.Options.TimeSeries.Granularity != nil {
+				timeSeries.SetGranularity(*collection.Options.TimeSeries.Granularity)
+			}
+
+			opts.TimeSeriesOptions = timeSeries
+		}
+
+		if collection.Options.ChangeStreamPreAndPostImages != nil {
+			opts.ChangeStreamPreAndPostImages = bson.D{
+				{Key: "enabled", Value: collection.Options.ChangeStreamPreAndPostImages.Enabled},
+			}
+		}
+
+		return nil
+	}
+}
+
+// CreateCollection isn't retried, a failed attempt may have created the collection already.
+func (c *Client) CreateCollection(ctx context.Context, collection *Collection) (*Collection, error) {
+	tflog.Debug(ctx, "CreateCollection", map[string]any{
+		"database": collection.Database,
+		"name":     collection.Name,
+	})
+
+	opts := options.CreateCollection()
+	opts.Opts = append(opts.Opts, setCollectionOptions(collection))
+
+	err := c.mongo.Database(collection.Database).CreateCollection(ctx, collection.Name, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error creating collection: %w", commandError(createCollectionCmd, err))
+	}
+
+	return c.GetCollection(ctx, &GetCollectionOptions{
+		Name:     collection.Name,
+		Database: collection.Database,
+	})
+}
+
+type GetCollectionOptions struct {
+	Name     string
+	Database string
+}
+
+func (c *Client) GetCollection(ctx context.Context, options *GetCollectionOptions) (*Collection, error) {
+	return withRetry(ctx, c, "GetCollection", func() (*Collection, error) {
+		return c.getCollection(ctx, options)
+	})
+}
+
+func (c *Client) getCollection(ctx context.Context, options *GetCollectionOptions) (*Collection, error) {
+	tflog.Debug(ctx, "GetCollection", map[string]any{
+		"database": options.Database,
+		"name":     options.Name,
+	})
+
+	var collections []Collection
+
+	err := c.listCollections(ctx, options.Database, bson.D{
+		{Key: "name", Value: options.Name},
+	}, &collections)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(collections) == 0:
+		return nil, NotFoundError{options.Name, "collection"}
+	case len(collections) > 1:
+		return nil, TooManyError{"collection"}
+	case collections[0].Type == viewType:
+		// Views share the namespace with collections, their viewOn and pipeline would be lost here
+		return nil, WrongTypeError{options.Name, "collection", viewType}
+	}
+
+	collections[0].Database = options.Database
+
+	return &collections[0], nil
+}
+
+// ModifyCollectionOptions contains collection options which can be changed in place.
+// Nil values are left untouched.
+type ModifyCollectionOptions struct {
+	Name                         string
+	Database                     string
+	Size                         *int64
+	Max                          *int64
+	Granularity                  *string
+	ExpireAfterSeconds           *int64
+	DisableExpiration            bool
+	ChangeStreamPreAndPostImages *bool
+	// Validator replaces the current validator, an empty document removes it
+	Validator        bson.D
+	ValidationLevel  *string
+	ValidationAction *string
+}
+
+func (c *Client) ModifyCollection(ctx context.Context, options *ModifyCollectionOptions) (*Collection, error) {
+	return withRetry(ctx, c, "ModifyCollection", func() (*Collection, error) {
+		return c.modifyCollection(ctx, options)
+	})
+}
+
+func (c *Client) modifyCollection(ctx context.Context, options *ModifyCollectionOptions) (*Collection, error) {
+	tflog.Debug(ctx, "ModifyCollection", map[string]any{
+		"database": options.Database,
+		"name":     options.Name,
+	})
+
+	command := bson.D{
+		{Key: modifyCollectionCmd, Value: options.Name},
+	}
+
+	if options.Size != nil {
+		command = append(command, bson.E{Key: "cappedSize", Value: *options.Size})
+	}
+
+	if options.Max != nil {
+		command = append(command, bson.E{Key: "cappedMax", Value: *options.Max})
+	}
+
+	if options.Granularity != nil {
+		command = append(command, bson.E{Key: "timeseries", Value: bson.D{
+			{Key: "granularity", Value: *options.Granularity},
+		}})
+	}
+
+	switch {
+	case options.DisableExpiration:
+		command = append(command, bson.E{Key: "expireAfterSeconds", Value: expirationOff})
+	case options.ExpireAfterSeconds != nil:
+		command = append(command, bson.E{Key: "expireAfterSeconds", Value: *options.ExpireAfterSeconds})
+	}
+
+	if options.ChangeStreamPreAndPostImages != nil {
+		command = append(command, bson.E{Key: "changeStreamPreAndPostImages", Value: bson.D{
+			{Key: "enabled", Value: *options.ChangeStreamPreAndPostImages},
+		}})
+	}
+
+	if options.Validator != nil {
+		command = append(command, bson.E{Key: "validator", Value: options.Validator})
+	}
+
+	if options.ValidationLevel != nil {
+		command = append(command, bson.E{Key: "validationLevel", Value: *options.ValidationLevel})
+	}
+
+	if options.ValidationAction != nil {
+		command = append(command, bson.E{Key: "validationAction", Value: *options.ValidationAction})
+	}
+
+	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error modifying collection: %w", commandError(modifyCollectionCmd, err))
+	}
+
+	var result Result
+
+	err = response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Ok != 1 {
+		return nil, FailedCommandError{Cmd: modifyCollectionCmd}
+	}
+
+	return c.getCollection(ctx, &GetCollectionOptions{
+		Name:     options.Name,
+		Database: options.Database,
+	})
+}
+
+// listCollections decodes listCollections results matching the filter into results.
+func (c *Client) listCollections(ctx context.Context, database string, filter bson.D, results any) error {
+	cursor, err := c.mongo.Database(database).ListCollections(ctx, filter)
+	if err != nil {
+		return commandError(listCollectionsCmd, err)
+	}
+
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			tflog.Error(ctx, "error closing cursor", map[string]any{
+				"err": err,
+			})
+		}
+	}(cursor, ctx)
+
+	return cursor.All(ctx, results)
+}
+
+// DropCollection can be retried, the driver ignores collections which don't exist.
+func (c *Client) DropCollection(ctx context.Context, options *GetCollectionOptions) error {
+	tflog.Debug(ctx, "DropCollection", map[string]any{
+		"database": options.Database,
+		"name":     options.Name,
+	})
+
+	_, err := withRetry(ctx, c, "DropCollection", func() (struct{}, error) {
+		err := c.mongo.Database(options.Database).Collection(options.Name).Drop(ctx)
+
+		return struct{}{}, commandError(dropCollectionCmd, err)
+	})
+
+	return err
+}
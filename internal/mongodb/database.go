@@ -0,0 +1,196 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	listDatabasesCmd = "listDatabases"
+	databaseStatsCmd = "dbStats"
+	dropDatabaseCmd  = "dropDatabase"
+)
+
+type CreateDatabaseOptions struct {
+	Name string
+	// MongoDB creates databases implicitly, so an initial collection is required to create one
+	InitialCollection string
+}
+
+// CreateDatabase can be retried, an initial collection left by a failed attempt is reused.
+func (c *Client) CreateDatabase(ctx context.Context, options *CreateDatabaseOptions) (*Database, error) {
+	return withRetry(ctx, c, "CreateDatabase", func() (*Database, error) {
+		return c.createDatabase(ctx, options)
+	})
+}
+
+func (c *Client) createDatabase(ctx context.Context, options *CreateDatabaseOptions) (*Database, error) {
+	tflog.Debug(ctx, "CreateDatabase", map[string]any{
+		"name":               options.Name,
+		"initial_collection": options.InitialCollection,
+	})
+
+	command := bson.D{
+		{Key: createCollectionCmd, Value: options.InitialCollection},
+	}
+
+	response := c.mongo.Database(options.Name).RunCommand(ctx, command)
+
+	err := response.Err()
+
+	var serverErr mongo.ServerError
+
+	switch {
+	case errors.As(err, &serverErr) && serverErr.HasErrorCode(NamespaceExistsCode):
+		tflog.Debug(ctx, "initial collection already exists", map[string]any{
+			"name":               options.Name,
+			"initial_collection": options.InitialCollection,
+		})
+	case err != nil:
+		return nil, commandError(createCollectionCmd, err)
+	default:
+		var result Result
+
+		err = response.Decode(&result)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Ok != 1 {
+			return nil, FailedCommandError{Cmd: createCollectionCmd}
+		}
+	}
+
+	return c.getDatabase(ctx, &GetDatabaseOptions{
+		Name: options.Name,
+	})
+}
+
+type GetDatabaseOptions struct {
+	Name string
+}
+
+type listDatabasesResult struct {
+	Ok        int `bson:"ok"`
+	Databases []struct {
+		Name string `bson:"name"`
+	} `bson:"databases"`
+}
+
+type databaseStatsResult struct {
+	Database `bson:"inline"`
+
+	Ok int `bson:"ok"`
+}
+
+func (c *Client) GetDatabase(ctx context.Context, options *GetDatabaseOptions) (*Database, error) {
+	return withRetry(ctx, c, "GetDatabase", func() (*Database, error) {
+		return c.getDatabase(ctx, options)
+	})
+}
+
+func (c *Client) getDatabase(ctx context.Context, options *GetDatabaseOptions) (*Database, error) {
+	tflog.Debug(ctx, "GetDatabase", map[string]any{
+		"name": options.Name,
+	})
+
+	// dbStats succeeds for databases that don't exist, so existence is checked with listDatabases
+	command := bson.D{
+		{Key: listDatabasesCmd, Value: 1},
+		{Key: "filter", Value: bson.D{{Key: "name", Value: options.Name}}},
+		{Key: "nameOnly", Value: true},
+	}
+
+	response := c.mongo.Database(adminDatabase).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return nil, commandError(listDatabasesCmd, err)
+	}
+
+	var listResult listDatabasesResult
+
+	err = response.Decode(&listResult)
+	if err != nil {
+		return nil, err
+	}
+
+	if listResult.Ok != 1 {
+		return nil, FailedCommandError{Cmd: listDatabasesCmd}
+	}
+
+	if len(listResult.Databases) == 0 {
+		return nil, NotFoundError{options.Name, "database"}
+	}
+
+	command = bson.D{
+		{Key: databaseStatsCmd, Value: 1},
+	}
+
+	response = c.mongo.Database(options.Name).RunCommand(ctx, command)
+
+	err = response.Err()
+	if err != nil {
+		return nil, commandError(databaseStatsCmd, err)
+	}
+
+	var statsResult databaseStatsResult
+
+	err = response.Decode(&statsResult)
+	if err != nil {
+		return nil, err
+	}
+
+	if statsResult.Ok != 1 {
+		return nil, FailedCommandError{Cmd: databaseStatsCmd}
+	}
+
+	return &statsResult.Database, nil
+}
+
+type DropDatabaseOptions struct {
+	Name string
+}
+
+// DropDatabase can be retried, dropDatabase succeeds for databases which don't exist.
+func (c *Client) DropDatabase(ctx context.Context, options *DropDatabaseOptions) error {
+	_, err := withRetry(ctx, c, "DropDatabase", func() (struct{}, error) {
+		return struct{}{}, c.dropDatabase(ctx, options)
+	})
+
+	return err
+}
+
+func (c *Client) dropDatabase(ctx context.Context, options *DropDatabaseOptions) error {
+	tflog.Debug(ctx, "DropDatabase", map[string]any{
+		"name": options.Name,
+	})
+
+	command := bson.D{
+		{Key: dropDatabaseCmd, Value: 1},
+	}
+
+	response := c.mongo.Database(options.Name).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return commandError(dropDatabaseCmd, err)
+	}
+
+	var result Result
+
+	err = response.Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if result.Ok != 1 {
+		return FailedCommandError{Cmd: dropDatabaseCmd}
+	}
+
+	return nil
+}
@@ -1,7 +1,29 @@
 package mongodb
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Server error codes worth a targeted diagnostic,
+// see https://github.com/mongodb/mongo/blob/master/src/mongo/base/error_codes.yml
+const (
+	BadValueCode              = 2
+	UserNotFoundCode          = 11
+	UnauthorizedCode          = 13
+	AuthenticationFailedCode  = 18
+	NamespaceNotFoundCode     = 26
+	IndexNotFoundCode         = 27
+	RoleNotFoundCode          = 31
+	NamespaceExistsCode       = 48
+	CannotCreateIndexCode     = 67
+	InvalidOptionsCode        = 72
+	IndexOptionsConflictCode  = 85
+	IndexKeySpecsConflictCode = 86
+	DuplicateKeyCode          = 11000
 )
 
 type NotFoundError struct {
@@ -13,6 +35,17 @@ func (e NotFoundError) Error() string {
 	return fmt.Sprintf("%s %s not found", e.name, e.t)
 }
 
+// WrongTypeError is a namespace which holds another kind of object, e.g. a view read as a collection.
+type WrongTypeError struct {
+	name   string
+	t      string
+	actual string
+}
+
+func (e WrongTypeError) Error() string {
+	return fmt.Sprintf("%s is a %s, not a %s", e.name, e.actual, e.t)
+}
+
 type TooManyError struct {
 	t string
 }
@@ -21,10 +54,72 @@ func (e TooManyError) Error() string {
 	return fmt.Sprintf("found too many %ss", e.t)
 }
 
+// FailedCommandError is a command rejected by the server. Code, CodeName and Message are set
+// when the server reported them, e.g. 13, "Unauthorized" and "not authorized on admin to execute command".
 type FailedCommandError struct {
-	Cmd string
+	Cmd      string
+	Code     int32
+	CodeName string
+	Message  string
+
+	err error
+}
+
+// commandError converts driver command errors, other errors, like network ones, are returned as is.
+// The driver error is kept, so its labels can still be checked.
+func commandError(cmd string, err error) error {
+	var driverErr mongo.CommandError
+	if !errors.As(err, &driverErr) {
+		return err
+	}
+
+	return FailedCommandError{
+		Cmd:      cmd,
+		Code:     driverErr.Code,
+		CodeName: driverErr.Name,
+		Message:  driverErr.Message,
+		err:      err,
+	}
 }
 
 func (e FailedCommandError) Error() string {
-	return e.Cmd + " command failed"
+	if e.Code == 0 {
+		return e.Cmd + " command failed"
+	}
+
+	codeName := e.CodeName
+	if codeName == "" {
+		codeName = fmt.Sprintf("code %d", e.Code)
+	}
+
+	return fmt.Sprintf("%s command failed: %s (%s)", e.Cmd, e.Message, codeName)
+}
+
+func (e FailedCommandError) Unwrap() error {
+	return e.err
+}
+
+// RolesChangeError reports roles or privileges which were already changed when a grant or revoke command failed.
+type RolesChangeError struct {
+	Granted []string
+	Revoked []string
+	Err     error
+}
+
+func (e RolesChangeError) Error() string {
+	msg := e.Err.Error()
+
+	if len(e.Granted) > 0 {
+		msg += "; already granted: " + strings.Join(e.Granted, ", ")
+	}
+
+	if len(e.Revoked) > 0 {
+		msg += "; already revoked: " + strings.Join(e.Revoked, ", ")
+	}
+
+	return msg
+}
+
+func (e RolesChangeError) Unwrap() error {
+	return e.Err
 }
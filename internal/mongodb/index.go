@@ -2,13 +2,24 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+const (
+	createIndexesCmd = "createIndexes"
+	listIndexesCmd   = "listIndexes"
+	dropIndexesCmd   = "dropIndexes"
+
+	prepareUniqueRevertTimeout = time.Minute
+)
+
 type GetIndexOptions struct {
 	Name       string
 	Database   string
@@ -48,6 +59,12 @@ func setIndexOptions(index *Index) func(*options.IndexOptions) error {
 }
 
 func (c *Client) CreateIndex(ctx context.Context, index *Index) (*Index, error) {
+	return withRetry(ctx, c, "CreateIndex", func() (*Index, error) {
+		return c.createIndex(ctx, index)
+	})
+}
+
+func (c *Client) createIndex(ctx context.Context, index *Index) (*Index, error) {
 	tflog.Debug(ctx, "CreateIndex", map[string]any{
 		"database":   index.Database,
 		"collection": index.Collection,
@@ -68,10 +85,10 @@ func (c *Client) CreateIndex(ctx context.Context, index *Index) (*Index, error)
 
 	_, err := collection.Indexes().CreateOne(ctx, indexModel)
 	if err != nil {
-		return nil, fmt.Errorf("error creating index: %w", err)
+		return nil, fmt.Errorf("error creating index: %w", commandError(createIndexesCmd, err))
 	}
 
-	return c.GetIndex(ctx, &GetIndexOptions{
+	return c.getIndex(ctx, &GetIndexOptions{
 		Name:       index.Name,
 		Database:   index.Database,
 		Collection: index.Collection,
@@ -79,11 +96,17 @@ func (c *Client) CreateIndex(ctx context.Context, index *Index) (*Index, error)
 }
 
 func (c *Client) GetIndex(ctx context.Context, opt *GetIndexOptions) (*Index, error) {
+	return withRetry(ctx, c, "GetIndex", func() (*Index, error) {
+		return c.getIndex(ctx, opt)
+	})
+}
+
+func (c *Client) getIndex(ctx context.Context, opt *GetIndexOptions) (*Index, error) {
 	collection := c.mongo.Database(opt.Database).Collection(opt.Collection)
 
 	cursor, err := collection.Indexes().List(ctx)
 	if err != nil {
-		return nil, err
+		return nil, commandError(listIndexesCmd, err)
 	}
 
 	defer func(cursor *mongo.Cursor, ctx context.Context) {
@@ -121,6 +144,124 @@ func (c *Client) GetIndex(ctx context.Context, opt *GetIndexOptions) (*Index, er
 	}
 }
 
+// ModifyIndexOptions contains index options which can be changed in place.
+// Nil values are left untouched.
+type ModifyIndexOptions struct {
+	Name               string
+	Database           string
+	Collection         string
+	Hidden             *bool
+	ExpireAfterSeconds *int32
+	// Unique can only be used to convert an index to unique, the reverse conversion is not supported by MongoDB.
+	Unique *bool
+}
+
+func (c *Client) ModifyIndex(ctx context.Context, options *ModifyIndexOptions) (*Index, error) {
+	tflog.Debug(ctx, "ModifyIndex", map[string]any{
+		"database":   options.Database,
+		"collection": options.Collection,
+		"name":       options.Name,
+	})
+
+	index := bson.D{
+		{Key: "name", Value: options.Name},
+	}
+
+	if options.Hidden != nil {
+		index = append(index, bson.E{Key: "hidden", Value: *options.Hidden})
+	}
+
+	if options.ExpireAfterSeconds != nil {
+		index = append(index, bson.E{Key: "expireAfterSeconds", Value: *options.ExpireAfterSeconds})
+	}
+
+	convertToUnique := options.Unique != nil && *options.Unique
+
+	// Conversion to a unique index is done in two steps:
+	// new duplicate entries are rejected with prepareUnique first, then the index is converted.
+	// prepareUnique is turned off again if the conversion fails.
+	if convertToUnique {
+		index = append(index, bson.E{Key: "prepareUnique", Value: true})
+	}
+
+	err := c.modifyIndex(ctx, options, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if convertToUnique {
+		err = c.modifyIndex(ctx, options, bson.D{
+			{Key: "name", Value: options.Name},
+			{Key: "unique", Value: true},
+		})
+		if err != nil {
+			return nil, c.revertPrepareUnique(ctx, options, err)
+		}
+	}
+
+	return c.GetIndex(ctx, &GetIndexOptions{
+		Name:       options.Name,
+		Database:   options.Database,
+		Collection: options.Collection,
+	})
+}
+
+// revertPrepareUnique turns prepareUnique off after the conversion to unique failed, e.g. on duplicate keys.
+// Otherwise the index would keep rejecting duplicates without being unique, which isn't visible in the state.
+func (c *Client) revertPrepareUnique(ctx context.Context, options *ModifyIndexOptions, err error) error {
+	// The conversion may have failed because ctx is done, the revert still needs to run
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), prepareUniqueRevertTimeout)
+	defer cancel()
+
+	revertErr := c.modifyIndex(ctx, options, bson.D{
+		{Key: "name", Value: options.Name},
+		{Key: "prepareUnique", Value: false},
+	})
+	if revertErr != nil {
+		return fmt.Errorf("%w; index %s is left with prepareUnique, so new duplicate keys are rejected, "+
+			"turning it off failed: %w", err, options.Name, revertErr)
+	}
+
+	return err
+}
+
+// modifyIndex runs a single collMod, each step of ModifyIndex is idempotent and retried on its own.
+func (c *Client) modifyIndex(ctx context.Context, options *ModifyIndexOptions, index bson.D) error {
+	_, err := withRetry(ctx, c, "ModifyIndex", func() (struct{}, error) {
+		return struct{}{}, c.runModifyIndex(ctx, options, index)
+	})
+
+	return err
+}
+
+func (c *Client) runModifyIndex(ctx context.Context, options *ModifyIndexOptions, index bson.D) error {
+	command := bson.D{
+		{Key: modifyCollectionCmd, Value: options.Collection},
+		{Key: "index", Value: index},
+	}
+
+	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return fmt.Errorf("error modifying index: %w", commandError(modifyCollectionCmd, err))
+	}
+
+	var result Result
+
+	err = response.Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if result.Ok != 1 {
+		return FailedCommandError{Cmd: modifyCollectionCmd}
+	}
+
+	return nil
+}
+
+// DeleteIndex succeeds if the index or its collection is already gone, e.g. dropped by a failed attempt.
 func (c *Client) DeleteIndex(ctx context.Context, options *GetIndexOptions) error {
 	tflog.Debug(ctx, "DeleteIndex", map[string]any{
 		"database":   options.Database,
@@ -130,5 +271,19 @@ func (c *Client) DeleteIndex(ctx context.Context, options *GetIndexOptions) erro
 
 	collection := c.mongo.Database(options.Database).Collection(options.Collection)
 
-	return collection.Indexes().DropOne(ctx, options.Name)
+	_, err := withRetry(ctx, c, "DeleteIndex", func() (struct{}, error) {
+		return struct{}{}, collection.Indexes().DropOne(ctx, options.Name)
+	})
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && (serverErr.HasErrorCode(IndexNotFoundCode) ||
+		serverErr.HasErrorCode(NamespaceNotFoundCode)) {
+		tflog.Debug(ctx, "index is already deleted", map[string]any{
+			"err": err,
+		})
+
+		return nil
+	}
+
+	return commandError(dropIndexesCmd, err)
 }
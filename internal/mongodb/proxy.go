@@ -0,0 +1,163 @@
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+const (
+	defaultProxyPort = 1080
+)
+
+// ProxyOptions configure a SOCKS5 proxy all connections are made through.
+// The driver doesn't support proxies, so they are implemented with a custom dialer.
+type ProxyOptions struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+func (p *ProxyOptions) validate() error {
+	switch {
+	case p.Host == "":
+		return errors.New("proxy port and credentials require proxy host")
+	case (p.Username == "") != (p.Password == ""):
+		return errors.New("proxy username and password must be set together")
+	}
+
+	return nil
+}
+
+func newProxyDialer(options *ProxyOptions) (proxy.ContextDialer, error) {
+	err := options.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	port := options.Port
+	if port == 0 {
+		port = defaultProxyPort
+	}
+
+	var auth *proxy.Auth
+
+	if options.Username != "" {
+		auth = &proxy.Auth{
+			User:     options.Username,
+			Password: options.Password,
+		}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", net.JoinHostPort(options.Host, strconv.Itoa(port)), auth, &net.Dialer{})
+	if err != nil {
+		return nil, err
+	}
+
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, errors.New("proxy dialer doesn't support contexts")
+	}
+
+	return contextDialer, nil
+}
+
+// proxyFromConnectionString reads proxyHost, proxyPort, proxyUsername and proxyPassword options,
+// which the driver parses, but ignores.
+func proxyFromConnectionString(connectionString string) (*ProxyOptions, error) {
+	_, rawQuery, found := strings.Cut(connectionString, "?")
+	if !found {
+		return nil, nil
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// Option names are case insensitive
+	values := map[string][]string{}
+	for key, value := range query {
+		values[strings.ToLower(key)] = append(values[strings.ToLower(key)], value...)
+	}
+
+	for _, key := range []string{"proxyhost", "proxyport", "proxyusername", "proxypassword"} {
+		if len(values[key]) > 1 {
+			return nil, fmt.Errorf("connection string has multiple %s options", key)
+		}
+	}
+
+	if len(values["proxyhost"]) == 0 && len(values["proxyport"]) == 0 &&
+		len(values["proxyusername"]) == 0 && len(values["proxypassword"]) == 0 {
+		return nil, nil
+	}
+
+	options := &ProxyOptions{}
+
+	if len(values["proxyhost"]) == 1 {
+		options.Host = values["proxyhost"][0]
+	}
+
+	if len(values["proxyport"]) == 1 {
+		options.Port, err = strconv.Atoi(values["proxyport"][0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxyPort in connection string: %w", err)
+		}
+	}
+
+	if len(values["proxyusername"]) == 1 {
+		options.Username = values["proxyusername"][0]
+	}
+
+	if len(values["proxypassword"]) == 1 {
+		options.Password = values["proxypassword"][0]
+	}
+
+	return options, nil
+}
+
+// mergeProxyOptions combines proxy settings from options and the connection string.
+// A setting can be given in both places only with the same value.
+func mergeProxyOptions(options, fromConnectionString *ProxyOptions) (*ProxyOptions, error) {
+	switch {
+	case options == nil:
+		return fromConnectionString, nil
+	case fromConnectionString == nil:
+		return options, nil
+	}
+
+	merged := *fromConnectionString
+
+	err := errors.Join(
+		mergeProxySetting("host", &merged.Host, options.Host),
+		mergeProxySetting("port", &merged.Port, options.Port),
+		mergeProxySetting("username", &merged.Username, options.Username),
+		mergeProxySetting("password", &merged.Password, options.Password),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &merged, nil
+}
+
+func mergeProxySetting[T comparable](name string, merged *T, value T) error {
+	var zero T
+
+	switch {
+	case value == zero:
+		return nil
+	case *merged != zero && *merged != value:
+		return fmt.Errorf("proxy %s conflicts with the connection string", name)
+	}
+
+	*merged = value
+
+	return nil
+}
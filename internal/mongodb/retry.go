@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	retryBaseDelay = 250 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
+// transientErrorCodes are returned while a replica set elects a new primary or a server shuts down.
+var transientErrorCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	9001,  // SocketException
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// transientErrorLabels are attached by the server and the driver to errors which are safe to retry.
+var transientErrorLabels = []string{
+	"RetryableWriteError",
+	"TransientTransactionError",
+}
+
+// isTransientError reports whether an operation failed because of the topology rather than its arguments.
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if mongo.IsNetworkError(err) {
+		return true
+	}
+
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+
+	for _, label := range transientErrorLabels {
+		if serverErr.HasErrorLabel(label) {
+			return true
+		}
+	}
+
+	for _, code := range transientErrorCodes {
+		if serverErr.HasErrorCode(code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withRetry runs an idempotent operation again while it fails with transient errors, up to MaxRetries times.
+// Delays grow exponentially with full jitter, so concurrent resources don't retry in lockstep.
+// Nothing is retried once ctx is done.
+func withRetry[T any](ctx context.Context, c *Client, operation string, fn func() (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := fn()
+		if err == nil || attempt >= c.MaxRetries || ctx.Err() != nil || !isTransientError(err) {
+			return result, err
+		}
+
+		//nolint:gosec // Jitter doesn't need a secure generator
+		delay := rand.N(min(retryBaseDelay<<min(attempt, 10), retryMaxDelay))
+
+		tflog.Warn(ctx, "retrying after transient error", map[string]any{
+			"operation": operation,
+			"attempt":   attempt + 1,
+			"delay":     delay.String(),
+			"error":     err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(delay):
+		}
+	}
+}
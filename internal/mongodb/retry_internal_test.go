@@ -0,0 +1,153 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "not a server error", err: errors.New("boom"), want: false},
+		{name: "HostUnreachable", err: mongo.CommandError{Code: 6}, want: true},
+		{name: "NetworkTimeout", err: mongo.CommandError{Code: 89}, want: true},
+		{name: "ShutdownInProgress", err: mongo.CommandError{Code: 91}, want: true},
+		{name: "PrimarySteppedDown", err: mongo.CommandError{Code: 189}, want: true},
+		{name: "NotWritablePrimary", err: mongo.CommandError{Code: 10107}, want: true},
+		{name: "InterruptedDueToReplStateChange", err: mongo.CommandError{Code: 11602}, want: true},
+		{name: "NotPrimaryNoSecondaryOk", err: mongo.CommandError{Code: 13435}, want: true},
+		{name: "Unauthorized", err: mongo.CommandError{Code: UnauthorizedCode}, want: false},
+		{name: "DuplicateKey", err: mongo.CommandError{Code: DuplicateKeyCode}, want: false},
+		{
+			name: "RetryableWriteError label",
+			err:  mongo.CommandError{Code: BadValueCode, Labels: []string{"RetryableWriteError"}},
+			want: true,
+		},
+		{
+			name: "TransientTransactionError label",
+			err:  mongo.CommandError{Labels: []string{"TransientTransactionError"}},
+			want: true,
+		},
+		{name: "network error", err: mongo.CommandError{Labels: []string{"NetworkError"}}, want: true},
+		{
+			name: "write concern error",
+			err: mongo.WriteException{
+				WriteConcernError: &mongo.WriteConcernError{Code: 10107},
+			},
+			want: true,
+		},
+		{
+			name: "wrapped by commandError",
+			err: fmt.Errorf("error modifying index: %w",
+				commandError(modifyCollectionCmd, mongo.CommandError{Code: 189})),
+			want: true,
+		},
+		{name: "context canceled", err: context.Canceled, want: false},
+		{name: "context deadline exceeded", err: context.DeadlineExceeded, want: false},
+		{
+			name: "deadline exceeded with a network label",
+			err: mongo.CommandError{
+				Labels:  []string{"NetworkError"},
+				Wrapped: context.DeadlineExceeded,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	// Driver errors aren't comparable, wrapping them lets errors.Is find the returned error
+	transient := fmt.Errorf("ping: %w", mongo.CommandError{Code: 10107})
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		t.Parallel()
+
+		client := &Client{ClientOptions: ClientOptions{MaxRetries: 1}}
+		calls := 0
+
+		got, err := withRetry(context.Background(), client, "test", func() (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, transient
+			}
+
+			return calls, nil
+		})
+		if err != nil || got != 2 {
+			t.Errorf("withRetry() = %d, %v, want 2, nil", got, err)
+		}
+	})
+
+	t.Run("stops after max retries", func(t *testing.T) {
+		t.Parallel()
+
+		client := &Client{ClientOptions: ClientOptions{MaxRetries: 1}}
+		calls := 0
+
+		_, err := withRetry(context.Background(), client, "test", func() (int, error) {
+			calls++
+
+			return 0, transient
+		})
+		if !errors.Is(err, transient) || calls != 2 {
+			t.Errorf("withRetry() error = %v after %d calls, want %v after 2 calls", err, calls, transient)
+		}
+	})
+
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		t.Parallel()
+
+		client := &Client{ClientOptions: ClientOptions{MaxRetries: 3}}
+		calls := 0
+		unauthorized := fmt.Errorf("ping: %w", mongo.CommandError{Code: UnauthorizedCode})
+
+		_, err := withRetry(context.Background(), client, "test", func() (int, error) {
+			calls++
+
+			return 0, unauthorized
+		})
+		if !errors.Is(err, unauthorized) || calls != 1 {
+			t.Errorf("withRetry() error = %v after %d calls, want %v after 1 call", err, calls, unauthorized)
+		}
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		client := &Client{ClientOptions: ClientOptions{MaxRetries: 3}}
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+
+		_, err := withRetry(ctx, client, "test", func() (int, error) {
+			calls++
+			cancel()
+
+			return 0, transient
+		})
+		if !errors.Is(err, transient) || calls != 1 {
+			t.Errorf("withRetry() error = %v after %d calls, want %v after 1 call", err, calls, transient)
+		}
+	})
+}
@@ -6,71 +6,158 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 const (
-	createRoleCmd = "createRole"
-	getRoleCmd    = "rolesInfo"
-	updateRoleCmd = "updateRole"
-	deleteRoleCmd = "dropRole"
+	createRoleCmd          = "createRole"
+	getRoleCmd             = "rolesInfo"
+	updateRoleCmd          = "updateRole"
+	deleteRoleCmd          = "dropRole"
+	grantPrivilegesCmd     = "grantPrivilegesToRole"
+	revokePrivilegesCmd    = "revokePrivilegesFromRole"
+	grantRolesToRoleCmd    = "grantRolesToRole"
+	revokeRolesFromRoleCmd = "revokeRolesFromRole"
+
+	roleAlreadyExistsCode = 51002
 )
 
+// UpsertRole creates the role or updates it in place.
+// Privileges and inherited roles of an existing role are changed with grant and revoke commands,
+// so only the difference is applied.
 func (c *Client) UpsertRole(ctx context.Context, role *Role) (*Role, error) {
+	return withRetry(ctx, c, "UpsertRole", func() (*Role, error) {
+		return c.upsertRole(ctx, role)
+	})
+}
+
+func (c *Client) upsertRole(ctx context.Context, role *Role) (*Role, error) {
 	tflog.Debug(ctx, "UpsertRole", map[string]any{
 		"name":     role.Name,
 		"database": role.Database,
 	})
 
-	var cmd string
-
-	_, err := c.GetRole(ctx, &GetRoleOptions{
+	getRoleOptions := &GetRoleOptions{
 		Name:     role.Name,
 		Database: role.Database,
-	})
+	}
+
+	current, err := c.getRole(ctx, getRoleOptions)
 
 	switch {
 	case errors.As(err, &NotFoundError{}):
-		cmd = createRoleCmd
-	case err == nil:
-		cmd = updateRoleCmd
-	default:
+		command := bson.D{
+			{Key: createRoleCmd, Value: role.Name},
+			{Key: "privileges", Value: role.Privileges.toBson()},
+			// Roles field is required, but empty array is fine
+			{Key: "roles", Value: role.Roles.toBson()},
+		}
+
+		if len(role.AuthenticationRestrictions) > 0 {
+			command = append(command, bson.E{
+				Key:   "authenticationRestrictions",
+				Value: role.AuthenticationRestrictions.toBson(),
+			})
+		}
+
+		err = c.runCommand(ctx, role.Database, command)
+
+		var serverErr mongo.ServerError
+		if !errors.As(err, &serverErr) || !serverErr.HasErrorCode(roleAlreadyExistsCode) {
+			if err != nil {
+				return nil, err
+			}
+
+			return c.getRole(ctx, getRoleOptions)
+		}
+
+		// The role was created concurrently, update it instead
+		tflog.Debug(ctx, "role already exists, updating it", map[string]any{
+			"name":     role.Name,
+			"database": role.Database,
+		})
+
+		current, err = c.getRole(ctx, getRoleOptions)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
 		return nil, err
 	}
 
-	command := bson.D{
-		{Key: cmd, Value: role.Name},
-		{Key: "privileges", Value: role.Privileges.toBson()},
-		// Roles field is required, but empty array is fine
-		{Key: "roles", Value: role.Roles.toBson()},
+	if !role.AuthenticationRestrictions.Equal(current.AuthenticationRestrictions) {
+		err = c.runCommand(ctx, role.Database, bson.D{
+			{Key: updateRoleCmd, Value: role.Name},
+			{Key: "authenticationRestrictions", Value: role.AuthenticationRestrictions.toBson()},
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	response := c.mongo.Database(role.Database).RunCommand(ctx, command)
-
-	err = response.Err()
+	err = c.updateRole(ctx, role, current)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &Result{}
+	return c.getRole(ctx, getRoleOptions)
+}
 
-	err = response.Decode(result)
-	if err != nil {
-		return nil, err
-	}
+// updateRole grants missing roles and privileges and then revokes extra ones.
+// If a command fails, the returned RolesChangeError lists changes which were already applied.
+func (c *Client) updateRole(ctx context.Context, role *Role, current *Role) error {
+	grantedRoles := role.Roles.Difference(current.Roles)
+	revokedRoles := current.Roles.Difference(role.Roles)
+	grantedPrivileges := role.Privileges.Difference(current.Privileges)
+	revokedPrivileges := current.Privileges.Difference(role.Privileges)
+
+	tflog.Debug(ctx, "UpdateRole", map[string]any{
+		"name":               role.Name,
+		"database":           role.Database,
+		"granted_roles":      grantedRoles.Strings(),
+		"revoked_roles":      revokedRoles.Strings(),
+		"granted_privileges": grantedPrivileges.Strings(),
+		"revoked_privileges": revokedPrivileges.Strings(),
+	})
 
-	if result.Ok != 1 {
-		return nil, FailedCommandError{cmd}
+	steps := []struct {
+		cmd     string
+		field   string
+		value   bson.A
+		changes []string
+		revoke  bool
+	}{
+		{grantRolesToRoleCmd, "roles", grantedRoles.toBson(), grantedRoles.Strings(), false},
+		{grantPrivilegesCmd, "privileges", grantedPrivileges.toBson(), grantedPrivileges.Strings(), false},
+		{revokePrivilegesCmd, "privileges", revokedPrivileges.toBson(), revokedPrivileges.Strings(), true},
+		{revokeRolesFromRoleCmd, "roles", revokedRoles.toBson(), revokedRoles.Strings(), true},
 	}
 
-	role, err = c.GetRole(ctx, &GetRoleOptions{
-		Name:     role.Name,
-		Database: role.Database,
-	})
-	if err != nil {
-		return nil, err
+	var applied RolesChangeError
+
+	for _, step := range steps {
+		if len(step.value) == 0 {
+			continue
+		}
+
+		err := c.runCommand(ctx, role.Database, bson.D{
+			{Key: step.cmd, Value: role.Name},
+			{Key: step.field, Value: step.value},
+		})
+		if err != nil {
+			applied.Err = err
+
+			return applied
+		}
+
+		if step.revoke {
+			applied.Revoked = append(applied.Revoked, step.changes...)
+		} else {
+			applied.Granted = append(applied.Granted, step.changes...)
+		}
 	}
 
-	return role, nil
+	return nil
 }
 
 type GetRoleOptions struct {
@@ -84,6 +171,12 @@ type getRoleResult struct {
 }
 
 func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, error) {
+	return withRetry(ctx, c, "GetRole", func() (*Role, error) {
+		return c.getRole(ctx, options)
+	})
+}
+
+func (c *Client) getRole(ctx context.Context, options *GetRoleOptions) (*Role, error) {
 	tflog.Debug(ctx, "GetRole", map[string]any{
 		"name":     options.Name,
 		"database": options.Database,
@@ -92,13 +185,14 @@ func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, e
 	command := bson.D{
 		{Key: getRoleCmd, Value: options.Name},
 		{Key: "showPrivileges", Value: true},
+		{Key: "showAuthenticationRestrictions", Value: true},
 	}
 
 	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
 
 	err := response.Err()
 	if err != nil {
-		return nil, err
+		return nil, commandError(getRoleCmd, err)
 	}
 
 	var result getRoleResult
@@ -109,7 +203,7 @@ func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, e
 	}
 
 	if result.Ok != 1 {
-		return nil, FailedCommandError{getRoleCmd}
+		return nil, FailedCommandError{Cmd: getRoleCmd}
 	}
 
 	roleCount := len(result.Roles)
@@ -124,6 +218,52 @@ func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, e
 	return &result.Roles[0], nil
 }
 
+// ListRolesOptions selects roles to list. rolesInfo can only list roles of one database.
+type ListRolesOptions struct {
+	Database         string
+	ShowBuiltinRoles bool
+}
+
+func (c *Client) ListRoles(ctx context.Context, options *ListRolesOptions) ([]Role, error) {
+	return withRetry(ctx, c, "ListRoles", func() ([]Role, error) {
+		return c.listRoles(ctx, options)
+	})
+}
+
+func (c *Client) listRoles(ctx context.Context, options *ListRolesOptions) ([]Role, error) {
+	tflog.Debug(ctx, "ListRoles", map[string]any{
+		"database":         options.Database,
+		"showBuiltinRoles": options.ShowBuiltinRoles,
+	})
+
+	command := bson.D{
+		{Key: getRoleCmd, Value: 1},
+		{Key: "showPrivileges", Value: true},
+		{Key: "showBuiltinRoles", Value: options.ShowBuiltinRoles},
+		{Key: "showAuthenticationRestrictions", Value: true},
+	}
+
+	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return nil, commandError(getRoleCmd, err)
+	}
+
+	var result getRoleResult
+
+	err = response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Ok != 1 {
+		return nil, FailedCommandError{Cmd: getRoleCmd}
+	}
+
+	return result.Roles, nil
+}
+
 type DeleteRoleOptions struct {
 	Name     string
 	Database string
@@ -143,7 +283,7 @@ func (c *Client) DeleteRole(ctx context.Context, options *DeleteRoleOptions) err
 
 	err := response.Err()
 	if err != nil {
-		return err
+		return commandError(deleteRoleCmd, err)
 	}
 
 	var result Result
@@ -154,7 +294,7 @@ func (c *Client) DeleteRole(ctx context.Context, options *DeleteRoleOptions) err
 	}
 
 	if result.Ok != 1 {
-		return FailedCommandError{deleteRoleCmd}
+		return FailedCommandError{Cmd: deleteRoleCmd}
 	}
 
 	return nil
@@ -0,0 +1,308 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	buildInfoCmd    = "buildInfo"
+	helloCmd        = "hello"
+	isMasterCmd     = "isMaster"
+	getParameterCmd = "getParameter"
+
+	commandNotFoundCode = 59
+	shardRouterMessage  = "isdbgrid"
+)
+
+// Flavor is the server implementation. Compatible servers report the MongoDB version they implement.
+type Flavor string
+
+const (
+	FlavorMongoDB    Flavor = "mongodb"
+	FlavorDocumentDB Flavor = "documentdb"
+	FlavorCosmosDB   Flavor = "cosmosdb"
+	FlavorFerretDB   Flavor = "ferretdb"
+)
+
+type Topology string
+
+const (
+	TopologyStandalone Topology = "standalone"
+	TopologyReplicaSet Topology = "replica_set"
+	TopologySharded    Topology = "sharded"
+)
+
+// hostFlavors tell managed services apart if their replies don't, e.g. through a proxy which rewrites them.
+var hostFlavors = map[string]Flavor{
+	".docdb.amazonaws.com":         FlavorDocumentDB,
+	".docdb-elastic.amazonaws.com": FlavorDocumentDB,
+	".cosmos.azure.com":            FlavorCosmosDB,
+}
+
+// ServerInfo describes the server the client is connected to.
+type ServerInfo struct {
+	// Flavor is detected from buildInfo and hello replies, with managed service host names as a fallback
+	Flavor   Flavor
+	Version  string
+	Topology Topology
+	// ReplicaSet is the replica set name, if the server is a member of one
+	ReplicaSet string
+	// Modules lists server modules, e.g. enterprise
+	Modules []string
+
+	versionArray []int
+}
+
+// VersionAtLeast compares the reported MongoDB version with major.minor.
+func (s *ServerInfo) VersionAtLeast(major, minor int) bool {
+	if len(s.versionArray) < 2 {
+		// Unknown version, let the server decide
+		return true
+	}
+
+	if s.versionArray[0] != major {
+		return s.versionArray[0] > major
+	}
+
+	return s.versionArray[1] >= minor
+}
+
+// ServerDetails is what the server currently reports about itself, unlike ServerInfo it's read on every call.
+type ServerDetails struct {
+	ServerInfo
+	GitVersion        string
+	IsWritablePrimary bool
+	// Hosts are the replica set members
+	Hosts          []string
+	MaxWireVersion int32
+	// LogicalSessionTimeoutMinutes is nil if the server doesn't support sessions
+	LogicalSessionTimeoutMinutes *int32
+	// FeatureCompatibilityVersion is empty if the server doesn't report it, e.g. some compatible servers
+	FeatureCompatibilityVersion string
+}
+
+type buildInfoResult struct {
+	Ok           int      `bson:"ok"`
+	Version      string   `bson:"version"`
+	GitVersion   string   `bson:"gitVersion"`
+	VersionArray []int    `bson:"versionArray"`
+	Modules      []string `bson:"modules"`
+	// FerretDB adds its own version to the result
+	FerretDB        bson.Raw `bson:"ferretdb"`
+	FerretDBVersion string   `bson:"ferretdbVersion"`
+	// CosmosType is the reply type, which Cosmos DB adds to its replies
+	CosmosType string `bson:"_t"`
+}
+
+// isDocumentDB reports replies without gitVersion and modules, which MongoDB always returns and DocumentDB doesn't.
+func (r *buildInfoResult) isDocumentDB() bool {
+	return r.GitVersion == "" && r.Modules == nil
+}
+
+type helloResult struct {
+	Ok                int  `bson:"ok"`
+	IsWritablePrimary bool `bson:"isWritablePrimary"`
+	// IsMaster is returned by isMaster instead of IsWritablePrimary
+	IsMaster                     bool     `bson:"ismaster"`
+	Msg                          string   `bson:"msg"`
+	SetName                      string   `bson:"setName"`
+	Hosts                        []string `bson:"hosts"`
+	MaxWireVersion               int32    `bson:"maxWireVersion"`
+	LogicalSessionTimeoutMinutes *int32   `bson:"logicalSessionTimeoutMinutes"`
+	// CosmosType is the reply type, which Cosmos DB adds to its replies
+	CosmosType string `bson:"_t"`
+}
+
+type featureCompatibilityVersionResult struct {
+	Ok int `bson:"ok"`
+	// FeatureCompatibilityVersion is a document since MongoDB 3.6 and a string before
+	FeatureCompatibilityVersion bson.RawValue `bson:"featureCompatibilityVersion"`
+}
+
+// Server returns what was detected about the server when the client connected, or nil before that.
+func (c *Client) Server() *ServerInfo {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	return c.server
+}
+
+func (c *Client) detectServer(ctx context.Context) (*ServerInfo, error) {
+	buildInfo, err := c.buildInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hello, err := c.hello(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	server := c.newServerInfo(buildInfo, hello)
+
+	tflog.Info(ctx, "detected MongoDB server", map[string]any{
+		"flavor":   server.Flavor,
+		"version":  server.Version,
+		"topology": server.Topology,
+	})
+
+	return server, nil
+}
+
+func (c *Client) GetServerDetails(ctx context.Context) (*ServerDetails, error) {
+	return withRetry(ctx, c, "GetServerDetails", func() (*ServerDetails, error) {
+		return c.getServerDetails(ctx)
+	})
+}
+
+func (c *Client) getServerDetails(ctx context.Context) (*ServerDetails, error) {
+	tflog.Debug(ctx, "GetServerDetails")
+
+	buildInfo, err := c.buildInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hello, err := c.hello(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	featureCompatibilityVersion, err := c.featureCompatibilityVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerDetails{
+		ServerInfo:                   *c.newServerInfo(buildInfo, hello),
+		GitVersion:                   buildInfo.GitVersion,
+		IsWritablePrimary:            hello.IsWritablePrimary || hello.IsMaster,
+		Hosts:                        hello.Hosts,
+		MaxWireVersion:               hello.MaxWireVersion,
+		LogicalSessionTimeoutMinutes: hello.LogicalSessionTimeoutMinutes,
+		FeatureCompatibilityVersion:  featureCompatibilityVersion,
+	}, nil
+}
+
+func (c *Client) newServerInfo(buildInfo *buildInfoResult, hello *helloResult) *ServerInfo {
+	server := &ServerInfo{
+		Flavor:       FlavorMongoDB,
+		Version:      buildInfo.Version,
+		Topology:     TopologyStandalone,
+		ReplicaSet:   hello.SetName,
+		Modules:      buildInfo.Modules,
+		versionArray: buildInfo.VersionArray,
+	}
+
+	switch {
+	case hello.Msg == shardRouterMessage:
+		server.Topology = TopologySharded
+	case hello.SetName != "":
+		server.Topology = TopologyReplicaSet
+	}
+
+	// Replies identify the flavor behind proxies, tunnels and DNS aliases, where host names don't
+	switch {
+	case len(buildInfo.FerretDB) > 0 || buildInfo.FerretDBVersion != "":
+		server.Flavor = FlavorFerretDB
+	case buildInfo.CosmosType != "" || hello.CosmosType != "":
+		server.Flavor = FlavorCosmosDB
+	case buildInfo.isDocumentDB():
+		server.Flavor = FlavorDocumentDB
+	default:
+		server.Flavor = c.hostFlavor()
+	}
+
+	return server
+}
+
+func (c *Client) buildInfo(ctx context.Context) (*buildInfoResult, error) {
+	var result buildInfoResult
+
+	err := c.mongo.Database(adminDatabase).RunCommand(ctx, bson.D{{Key: buildInfoCmd, Value: 1}}).Decode(&result)
+	if err != nil {
+		return nil, commandError(buildInfoCmd, err)
+	}
+
+	return &result, nil
+}
+
+// hello falls back to isMaster, which is the only one supported by older servers and some compatible ones.
+func (c *Client) hello(ctx context.Context) (*helloResult, error) {
+	var result helloResult
+
+	err := c.mongo.Database(adminDatabase).RunCommand(ctx, bson.D{{Key: helloCmd, Value: 1}}).Decode(&result)
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(commandNotFoundCode) {
+		err = c.mongo.Database(adminDatabase).RunCommand(ctx, bson.D{{Key: isMasterCmd, Value: 1}}).Decode(&result)
+	}
+
+	if err != nil {
+		return nil, commandError(helloCmd, err)
+	}
+
+	return &result, nil
+}
+
+// featureCompatibilityVersion returns an empty string if the server rejects the parameter,
+// e.g. compatible servers which don't implement it or users without the privilege to read it.
+func (c *Client) featureCompatibilityVersion(ctx context.Context) (string, error) {
+	var result featureCompatibilityVersionResult
+
+	err := c.mongo.Database(adminDatabase).RunCommand(ctx, bson.D{
+		{Key: getParameterCmd, Value: 1},
+		{Key: "featureCompatibilityVersion", Value: 1},
+	}).Decode(&result)
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && !isTransientError(err) {
+		tflog.Warn(ctx, "error reading featureCompatibilityVersion", map[string]any{
+			"err": err,
+		})
+
+		return "", nil
+	}
+
+	if err != nil {
+		return "", commandError(getParameterCmd, err)
+	}
+
+	if version, ok := result.FeatureCompatibilityVersion.StringValueOK(); ok {
+		return version, nil
+	}
+
+	if document, ok := result.FeatureCompatibilityVersion.DocumentOK(); ok {
+		if version, ok := document.Lookup("version").StringValueOK(); ok {
+			return version, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (c *Client) hostFlavor() Flavor {
+	for _, address := range c.hosts {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+
+		host = strings.ToLower(host)
+
+		for suffix, flavor := range hostFlavors {
+			if strings.HasSuffix(host, suffix) {
+				return flavor
+			}
+		}
+	}
+
+	return FlavorMongoDB
+}
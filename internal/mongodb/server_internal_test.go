@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestNewServerInfoFlavor(t *testing.T) {
+	t.Parallel()
+
+	mongodbBuildInfo := bson.D{
+		{Key: "version", Value: "7.0.2"},
+		{Key: "gitVersion", Value: "02b3c655e1302209ef046da6ba3ef6749dd0b62a"},
+		{Key: "modules", Value: bson.A{}},
+	}
+
+	tests := []struct {
+		name      string
+		hosts     []string
+		buildInfo bson.D
+		hello     bson.D
+		want      Flavor
+	}{
+		{
+			name:      "mongodb",
+			hosts:     []string{"localhost:27017"},
+			buildInfo: mongodbBuildInfo,
+			want:      FlavorMongoDB,
+		},
+		{
+			name:  "ferretdb",
+			hosts: []string{"localhost:27017"},
+			buildInfo: append(bson.D{
+				{Key: "ferretdbVersion", Value: "v1.24.0"},
+			}, mongodbBuildInfo...),
+			want: FlavorFerretDB,
+		},
+		{
+			name:      "documentdb through a tunnel",
+			hosts:     []string{"localhost:27017"},
+			buildInfo: bson.D{{Key: "version", Value: "5.0.0"}},
+			want:      FlavorDocumentDB,
+		},
+		{
+			name:  "cosmosdb buildInfo through a DNS alias",
+			hosts: []string{"mongo.internal.example.com:10255"},
+			buildInfo: bson.D{
+				{Key: "_t", Value: "BuildInfoResponse"},
+				{Key: "version", Value: "4.2.0"},
+			},
+			want: FlavorCosmosDB,
+		},
+		{
+			name:      "cosmosdb hello",
+			hosts:     []string{"localhost:10255"},
+			buildInfo: bson.D{{Key: "version", Value: "4.2.0"}},
+			hello:     bson.D{{Key: "_t", Value: "IsMasterResponse"}},
+			want:      FlavorCosmosDB,
+		},
+		{
+			name:      "documentdb host name",
+			hosts:     []string{"cluster.cluster-abc.eu-west-1.docdb.amazonaws.com:27017"},
+			buildInfo: mongodbBuildInfo,
+			want:      FlavorDocumentDB,
+		},
+		{
+			name:      "cosmosdb host name",
+			hosts:     []string{"ACCOUNT.mongo.cosmos.azure.com:10255"},
+			buildInfo: mongodbBuildInfo,
+			want:      FlavorCosmosDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buildInfo buildInfoResult
+
+			unmarshalDocument(t, tt.buildInfo, &buildInfo)
+
+			var hello helloResult
+
+			unmarshalDocument(t, tt.hello, &hello)
+
+			client := &Client{hosts: tt.hosts}
+
+			if got := client.newServerInfo(&buildInfo, &hello).Flavor; got != tt.want {
+				t.Errorf("newServerInfo().Flavor = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// unmarshalDocument decodes doc like a server reply, so missing fields stay unset.
+func unmarshalDocument(t *testing.T, doc bson.D, result any) {
+	t.Helper()
+
+	if doc == nil {
+		doc = bson.D{}
+	}
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = bson.Unmarshal(data, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,261 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	defaultSSHPort = 22
+)
+
+// SSHTunnelOptions configure a jump host all connections are made through.
+type SSHTunnelOptions struct {
+	Host string
+	Port int
+	User string
+
+	PrivateKey           string
+	PrivateKeyPassphrase string
+	// UseAgent authenticates with keys from the agent listening on SSH_AUTH_SOCK
+	UseAgent bool
+
+	// KnownHosts is the content of a known_hosts file, ~/.ssh/known_hosts is used if it's empty
+	KnownHosts            string
+	InsecureIgnoreHostKey bool
+}
+
+// sshDialer opens connections to MongoDB servers through a single SSH connection.
+// The SSH connection is established on the first dial and re-established if it breaks.
+type sshDialer struct {
+	address string
+	config  *ssh.ClientConfig
+	// agentSocket is set if keys are requested from the SSH agent
+	agentSocket string
+
+	mu     sync.Mutex
+	client *ssh.Client
+	// agent is the agent connection used by client, it's closed when a broken client is reset
+	agent net.Conn
+}
+
+func newSSHDialer(options *SSHTunnelOptions) (*sshDialer, error) {
+	if options.Host == "" || options.User == "" {
+		return nil, errors.New("ssh tunnel host and user are required")
+	}
+
+	auth, err := sshAuthMethods(options)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := sshHostKeyCallback(options)
+	if err != nil {
+		return nil, err
+	}
+
+	port := options.Port
+	if port == 0 {
+		port = defaultSSHPort
+	}
+
+	dialer := &sshDialer{
+		address: net.JoinHostPort(options.Host, strconv.Itoa(port)),
+		config: &ssh.ClientConfig{
+			User:            options.User,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+		},
+	}
+
+	if options.UseAgent {
+		dialer.agentSocket = os.Getenv("SSH_AUTH_SOCK")
+	}
+
+	return dialer, nil
+}
+
+func (d *sshDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	client, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.DialContext(ctx, network, address)
+	if err == nil || ctx.Err() != nil {
+		return conn, err
+	}
+
+	// The SSH connection may be broken, try once more with a new one
+	tflog.Debug(ctx, "reconnecting ssh tunnel", map[string]interface{}{
+		"address": d.address,
+		"error":   err.Error(),
+	})
+
+	d.reset(client)
+
+	client, err = d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.DialContext(ctx, network, address)
+}
+
+func (d *sshDialer) connect(ctx context.Context) (*ssh.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.client != nil {
+		return d.client, nil
+	}
+
+	tflog.Debug(ctx, "opening ssh tunnel", map[string]interface{}{
+		"address": d.address,
+		"user":    d.config.User,
+	})
+
+	var dialer net.Dialer
+
+	config := *d.config
+
+	if d.agentSocket != "" {
+		// The agent connection is kept open with the client, signers are requested on the handshake
+		agentConn, err := dialer.DialContext(ctx, "unix", d.agentSocket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to ssh agent: %w", err)
+		}
+
+		d.agent = agentConn
+		config.Auth = append(slices.Clone(config.Auth), ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers))
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", d.address)
+	if err != nil {
+		d.closeLocked()
+
+		return nil, fmt.Errorf("failed to connect to ssh host %s: %w", d.address, err)
+	}
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, d.address, &config)
+	if err != nil {
+		_ = conn.Close()
+		d.closeLocked()
+
+		return nil, fmt.Errorf("failed to open ssh session with %s: %w", d.address, err)
+	}
+
+	d.client = ssh.NewClient(sshConn, channels, requests)
+
+	return d.client, nil
+}
+
+// reset drops the client unless another dial has already replaced it.
+func (d *sshDialer) reset(client *ssh.Client) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.client == client {
+		d.closeLocked()
+	}
+}
+
+// closeLocked closes the client and its agent connection, so a broken client doesn't leak the agent connection.
+// Connections in use live as long as the provider process, the framework doesn't notify providers on shutdown.
+func (d *sshDialer) closeLocked() {
+	if d.client != nil {
+		_ = d.client.Close()
+		d.client = nil
+	}
+
+	if d.agent != nil {
+		_ = d.agent.Close()
+		d.agent = nil
+	}
+}
+
+func sshAuthMethods(options *SSHTunnelOptions) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if options.PrivateKey != "" {
+		var (
+			signer ssh.Signer
+			err    error
+		)
+
+		if options.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(
+				[]byte(options.PrivateKey),
+				[]byte(options.PrivateKeyPassphrase),
+			)
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(options.PrivateKey))
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh private key: %w", err)
+		}
+
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+
+	// The agent is connected by sshDialer, which owns the connection
+	if options.UseAgent && os.Getenv("SSH_AUTH_SOCK") == "" {
+		return nil, errors.New("ssh agent is requested, but SSH_AUTH_SOCK is not set")
+	}
+
+	if len(methods) == 0 && !options.UseAgent {
+		return nil, errors.New("ssh tunnel requires a private key or an agent")
+	}
+
+	return methods, nil
+}
+
+func sshHostKeyCallback(options *SSHTunnelOptions) (ssh.HostKeyCallback, error) {
+	if options.InsecureIgnoreHostKey {
+		//nolint:gosec // Explicitly requested, e.g. for ephemeral bastions
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	if options.KnownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find known_hosts: %w", err)
+		}
+
+		return knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	}
+
+	// knownhosts only reads files
+	file, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	_, err = file.WriteString(options.KnownHosts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return knownhosts.New(file.Name())
+}
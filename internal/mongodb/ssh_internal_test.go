@@ -0,0 +1,362 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// testSSHServer is a minimal sshd stand-in which only forwards direct-tcpip channels.
+type testSSHServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+
+	handshakes atomic.Int32
+
+	mu    sync.Mutex
+	conns []*ssh.ServerConn
+}
+
+func newTestSSHServer(t *testing.T, authorized ssh.PublicKey) *testSSHServer {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorized.Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+
+			return &ssh.Permissions{}, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &testSSHServer{listener: listener, config: config}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+		server.dropConnections()
+	})
+
+	go server.serve()
+
+	return server
+}
+
+func (s *testSSHServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *testSSHServer) handle(conn net.Conn) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		_ = conn.Close()
+
+		return
+	}
+
+	s.handshakes.Add(1)
+
+	s.mu.Lock()
+	s.conns = append(s.conns, serverConn)
+	s.mu.Unlock()
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "only direct-tcpip is supported")
+
+			continue
+		}
+
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+
+		err := ssh.Unmarshal(newChannel.ExtraData(), &target)
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+
+			continue
+		}
+
+		targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			_ = targetConn.Close()
+
+			continue
+		}
+
+		go ssh.DiscardRequests(channelRequests)
+
+		go func() {
+			_, _ = io.Copy(channel, targetConn)
+			_ = channel.CloseWrite()
+		}()
+
+		go func() {
+			_, _ = io.Copy(targetConn, channel)
+			_ = targetConn.Close()
+		}()
+	}
+}
+
+// dropConnections breaks established tunnels, like a bastion restart would.
+func (s *testSSHServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+
+	s.conns = nil
+}
+
+func (s *testSSHServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// newEchoServer stands in for a MongoDB server behind the bastion.
+func newEchoServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func newTestKey(t *testing.T) (ed25519.PrivateKey, ssh.PublicKey) {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, publicKey
+}
+
+func assertEcho(t *testing.T, dialer *sshDialer, address string) {
+	t.Helper()
+
+	conn, err := dialer.DialContext(context.Background(), "tcp", address)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Write([]byte("ping"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	buf := make([]byte, 4)
+
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if string(buf) != "ping" {
+		t.Fatalf("Read() = %q, want %q", buf, "ping")
+	}
+}
+
+func TestSSHDialerPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	key, publicKey := newTestKey(t)
+	server := newTestSSHServer(t, publicKey)
+	echo := newEchoServer(t)
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dialer, err := newSSHDialer(&SSHTunnelOptions{
+		Host:                  "127.0.0.1",
+		Port:                  server.port(),
+		User:                  "tunnel",
+		PrivateKey:            string(pem.EncodeToMemory(block)),
+		InsecureIgnoreHostKey: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("dial through", func(t *testing.T) {
+		assertEcho(t, dialer, echo)
+		assertEcho(t, dialer, echo)
+
+		if got := server.handshakes.Load(); got != 1 {
+			t.Errorf("handshakes = %d, want 1, the ssh connection must be reused", got)
+		}
+	})
+
+	t.Run("reconnect once", func(t *testing.T) {
+		server.dropConnections()
+
+		assertEcho(t, dialer, echo)
+
+		if got := server.handshakes.Load(); got != 2 {
+			t.Errorf("handshakes = %d, want 2 after the ssh connection was dropped", got)
+		}
+	})
+}
+
+// The agent test sets SSH_AUTH_SOCK, so it can't run in parallel.
+//
+//nolint:paralleltest
+func TestSSHDialerAgent(t *testing.T) {
+	key, publicKey := newTestKey(t)
+	server := newTestSSHServer(t, publicKey)
+	echo := newEchoServer(t)
+
+	keyring := agent.NewKeyring()
+
+	err := keyring.Add(agent.AddedKey{PrivateKey: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	// served receives a channel per agent connection, it's closed once the dialer closes the connection
+	served := make(chan chan struct{}, 2)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			done := make(chan struct{})
+			served <- done
+
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+
+				close(done)
+			}()
+		}
+	}()
+
+	t.Setenv("SSH_AUTH_SOCK", socket)
+
+	dialer, err := newSSHDialer(&SSHTunnelOptions{
+		Host:                  "127.0.0.1",
+		Port:                  server.port(),
+		User:                  "tunnel",
+		UseAgent:              true,
+		InsecureIgnoreHostKey: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEcho(t, dialer, echo)
+
+	first := <-served
+
+	server.dropConnections()
+
+	assertEcho(t, dialer, echo)
+
+	if got := server.handshakes.Load(); got != 2 {
+		t.Errorf("handshakes = %d, want 2 after the ssh connection was dropped", got)
+	}
+
+	// ServeAgent returns once the dialer closes its end of the agent connection
+	select {
+	case <-first:
+	case <-time.After(5 * time.Second):
+		t.Fatal("agent connection of the broken ssh connection is still open")
+	}
+}
@@ -0,0 +1,107 @@
+package mongodb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/youmark/pkcs8"
+)
+
+func newTLSConfig(options *ClientOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+
+	if options.Certificate != "" {
+		certPool := x509.NewCertPool()
+
+		ok := certPool.AppendCertsFromPEM([]byte(options.Certificate))
+		if !ok {
+			return nil, errors.New("failed to parse certificate")
+		}
+
+		tlsConfig.RootCAs = certPool
+	}
+
+	if options.ClientCertificate != "" {
+		certificate, err := loadClientCertificate(
+			options.ClientCertificate,
+			options.ClientKey,
+			options.ClientKeyPassphrase,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadClientCertificate builds a key pair from PEM strings.
+// The key can be a part of the certificate PEM, and it can be encrypted
+// either with a legacy PEM header or as PKCS #8.
+func loadClientCertificate(certificate, key, passphrase string) (tls.Certificate, error) {
+	if key == "" {
+		key = certificate
+	}
+
+	var keyBlock *pem.Block
+
+	for rest := []byte(key); ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			keyBlock = block
+
+			break
+		}
+	}
+
+	if keyBlock == nil {
+		return tls.Certificate{}, errors.New("failed to find private key")
+	}
+
+	//nolint:staticcheck // Legacy encrypted keys are still produced by openssl
+	legacyEncrypted := x509.IsEncryptedPEMBlock(keyBlock)
+	pkcs8Encrypted := keyBlock.Type == "ENCRYPTED PRIVATE KEY"
+
+	if (legacyEncrypted || pkcs8Encrypted) && passphrase == "" {
+		return tls.Certificate{}, errors.New("private key is encrypted, but no passphrase is provided")
+	}
+
+	switch {
+	case legacyEncrypted:
+		//nolint:staticcheck // Legacy encrypted keys are still produced by openssl
+		der, err := x509.DecryptPEMBlock(keyBlock, []byte(passphrase))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("failed to decrypt private key: %w", err)
+		}
+
+		keyBlock = &pem.Block{Type: keyBlock.Type, Bytes: der}
+	case pkcs8Encrypted:
+		privateKey, err := pkcs8.ParsePKCS8PrivateKey(keyBlock.Bytes, []byte(passphrase))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("failed to decrypt private key: %w", err)
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+
+		keyBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	return tls.X509KeyPair([]byte(certificate), pem.EncodeToMemory(keyBlock))
+}
@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"slices"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuthenticationRestriction limits addresses a user can connect from and to.
+type AuthenticationRestriction struct {
+	ClientSource  []string `bson:"clientSource,omitempty"`
+	ServerAddress []string `bson:"serverAddress,omitempty"`
+}
+
+func (r AuthenticationRestriction) equal(other AuthenticationRestriction) bool {
+	return equalUnordered(r.ClientSource, other.ClientSource) &&
+		equalUnordered(r.ServerAddress, other.ServerAddress)
+}
+
+type AuthenticationRestrictions []AuthenticationRestriction
+
+// UnmarshalBSONValue accepts both a list of restrictions (usersInfo)
+// and a list of restriction lists (rolesInfo).
+func (r *AuthenticationRestrictions) UnmarshalBSONValue(typ byte, data []byte) error {
+	if bson.Type(typ) != bson.TypeArray {
+		*r = nil
+
+		return nil
+	}
+
+	var values []bson.RawValue
+
+	err := bson.RawValue{Type: bson.Type(typ), Value: data}.Unmarshal(&values)
+	if err != nil {
+		return err
+	}
+
+	restrictions := AuthenticationRestrictions{}
+
+	for _, value := range values {
+		switch value.Type {
+		case bson.TypeEmbeddedDocument:
+			var restriction AuthenticationRestriction
+
+			err = value.Unmarshal(&restriction)
+			if err != nil {
+				return err
+			}
+
+			restrictions = append(restrictions, restriction)
+		case bson.TypeArray:
+			var nested AuthenticationRestrictions
+
+			err = nested.UnmarshalBSONValue(byte(value.Type), value.Value)
+			if err != nil {
+				return err
+			}
+
+			restrictions = append(restrictions, nested...)
+		}
+	}
+
+	*r = restrictions
+
+	return nil
+}
+
+// Equal compares restrictions ignoring order.
+func (r AuthenticationRestrictions) Equal(other AuthenticationRestrictions) bool {
+	if len(r) != len(other) {
+		return false
+	}
+
+	for _, restriction := range r {
+		if !slices.ContainsFunc(other, restriction.equal) {
+			return false
+		}
+	}
+
+	for _, restriction := range other {
+		if !slices.ContainsFunc(r, restriction.equal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r AuthenticationRestrictions) toBson() bson.A {
+	out := bson.A{}
+
+	for _, restriction := range r {
+		out = append(out, restriction)
+	}
+
+	return out
+}
+
+func equalUnordered(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
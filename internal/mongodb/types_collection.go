@@ -0,0 +1,77 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ClusteredIndex describes the clustered index of a collection. Its key is always {_id: 1}.
+type ClusteredIndex struct {
+	Key    bson.D  `bson:"key"`
+	Unique bool    `bson:"unique"`
+	Name   *string `bson:"name,omitempty"`
+}
+
+// UnmarshalBSONValue skips boolean values, which some server versions report for time series collections.
+func (c *ClusteredIndex) UnmarshalBSONValue(typ byte, data []byte) error {
+	if bson.Type(typ) != bson.TypeEmbeddedDocument {
+		return nil
+	}
+
+	type clusteredIndex ClusteredIndex
+
+	return bson.Unmarshal(data, (*clusteredIndex)(c))
+}
+
+func (c *ClusteredIndex) IsSet() bool {
+	return c != nil && len(c.Key) > 0
+}
+
+type TimeSeriesOptions struct {
+	TimeField   string  `bson:"timeField"`
+	MetaField   *string `bson:"metaField,omitempty"`
+	Granularity *string `bson:"granularity,omitempty"`
+}
+
+type ChangeStreamPreAndPostImages struct {
+	Enabled bool `bson:"enabled"`
+}
+
+type CollectionOptions struct {
+	Capped                       *bool                         `bson:"capped,omitempty"`
+	Size                         *int64                        `bson:"size,omitempty"`
+	Max                          *int64                        `bson:"max,omitempty"`
+	ClusteredIndex               *ClusteredIndex               `bson:"clusteredIndex,omitempty"`
+	TimeSeries                   *TimeSeriesOptions            `bson:"timeseries,omitempty"`
+	ExpireAfterSeconds           *int64                        `bson:"expireAfterSeconds,omitempty"`
+	ChangeStreamPreAndPostImages *ChangeStreamPreAndPostImages `bson:"changeStreamPreAndPostImages,omitempty"`
+	Collation                    *options.Collation            `bson:"collation,omitempty"`
+	Validator                    bson.D                        `bson:"validator,omitempty"`
+	ValidationLevel              *string                       `bson:"validationLevel,omitempty"`
+	ValidationAction             *string                       `bson:"validationAction,omitempty"`
+}
+
+type Collection struct {
+	Name     string            `bson:"name"`
+	Database string            `bson:"-"` // Not in MongoDB response
+	Type     string            `bson:"type"`
+	Options  CollectionOptions `bson:"options"`
+}
+
+const (
+	minCappedSize       = 4096
+	cappedSizeAlignment = 256
+)
+
+// CappedSize returns the size MongoDB actually allocates for a capped collection of the requested size.
+func CappedSize(size int64) int64 {
+	if size <= minCappedSize {
+		return minCappedSize
+	}
+
+	if remainder := size % cappedSizeAlignment; remainder != 0 {
+		size += cappedSizeAlignment - remainder
+	}
+
+	return size
+}
@@ -0,0 +1,13 @@
+package mongodb
+
+type Database struct {
+	Name        string `bson:"db"`
+	Collections int64  `bson:"collections"`
+	Views       int64  `bson:"views"`
+	Objects     int64  `bson:"objects"`
+}
+
+// IsEmpty reports whether the database contains no documents.
+func (d *Database) IsEmpty() bool {
+	return d.Objects == 0
+}
@@ -7,24 +7,31 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-type IndexKeys map[string]interface{}
+// IndexKey is a single index field with its direction (1, -1) or index type (e.g. "text", "2dsphere").
+type IndexKey struct {
+	Field     string
+	Direction interface{}
+}
+
+// IndexKeys keeps index fields in the order they were defined, which matters for compound indexes.
+type IndexKeys []IndexKey
 
 type IndexOptions struct {
-	Unique                  *bool                  `bson:"unique,omitempty"`
-	Sparse                  *bool                  `bson:"sparse,omitempty"`
-	Hidden                  *bool                  `bson:"hidden,omitempty"`
-	PartialFilterExpression map[string]interface{} `bson:"partialFilterExpression,omitempty"`
-	WildcardProjection      map[string]int32       `bson:"wildcardProjection,omitempty"`
-	Collation               *options.Collation     `bson:"collation,omitempty"`
-	ExpireAfterSeconds      *int32                 `bson:"expireAfterSeconds,omitempty"`
-	SphereVersion           *int32                 `bson:"2dSphereVersion,omitempty"`
-	Bits                    *int32                 `bson:"bits,omitempty"`
-	Min                     *float64               `bson:"min,omitempty"`
-	Max                     *float64               `bson:"max,omitempty"`
-	Weights                 map[string]int32       `bson:"weights,omitempty"`
-	DefaultLanguage         *string                `bson:"default_language,omitempty"`
-	LanguageOverride        *string                `bson:"language_override,omitempty"`
-	TextIndexVersion        *int32                 `bson:"textIndexVersion,omitempty"`
+	Unique                  *bool              `bson:"unique,omitempty"`
+	Sparse                  *bool              `bson:"sparse,omitempty"`
+	Hidden                  *bool              `bson:"hidden,omitempty"`
+	PartialFilterExpression bson.D             `bson:"partialFilterExpression,omitempty"`
+	WildcardProjection      map[string]int32   `bson:"wildcardProjection,omitempty"`
+	Collation               *options.Collation `bson:"collation,omitempty"`
+	ExpireAfterSeconds      *int32             `bson:"expireAfterSeconds,omitempty"`
+	SphereVersion           *int32             `bson:"2dSphereVersion,omitempty"`
+	Bits                    *int32             `bson:"bits,omitempty"`
+	Min                     *float64           `bson:"min,omitempty"`
+	Max                     *float64           `bson:"max,omitempty"`
+	Weights                 map[string]int32   `bson:"weights,omitempty"`
+	DefaultLanguage         *string            `bson:"default_language,omitempty"`
+	LanguageOverride        *string            `bson:"language_override,omitempty"`
+	TextIndexVersion        *int32             `bson:"textIndexVersion,omitempty"`
 }
 
 type Index struct {
@@ -35,47 +42,55 @@ type Index struct {
 	Options    IndexOptions `bson:"inline"` // Inline embedding
 }
 
-func (k IndexKeys) ToStringMap() map[string]string {
-	out := map[string]string{}
+// NewIndexKey converts the string representation of the direction used by terraform into the MongoDB one.
+func NewIndexKey(field, direction string) IndexKey {
+	key := IndexKey{Field: field}
+
+	switch direction {
+	case "1":
+		key.Direction = 1
+	case "-1":
+		key.Direction = -1
+	default:
+		key.Direction = direction
+	}
 
-	for field, value := range k {
-		var ok bool
+	return key
+}
 
-		out[field], ok = value.(string)
-		if !ok {
-			out[field] = fmt.Sprintf("%v", value)
-		}
+func (k IndexKey) DirectionString() string {
+	direction, ok := k.Direction.(string)
+	if !ok {
+		direction = fmt.Sprintf("%v", k.Direction)
 	}
 
-	return out
+	return direction
 }
 
-func ConvertMap(k map[string]string, indexKeys bool) map[string]interface{} {
-	out := map[string]interface{}{}
-
-	for field, value := range k {
-		if indexKeys {
-			switch value {
-			case "1":
-				out[field] = 1
-			case "-1":
-				out[field] = -1
-			default:
-				out[field] = value
-			}
-		} else {
-			out[field] = value
-		}
+func (k *IndexKeys) UnmarshalBSON(data []byte) error {
+	var doc bson.D
+
+	err := bson.Unmarshal(data, &doc)
+	if err != nil {
+		return err
 	}
 
-	return out
+	keys := make(IndexKeys, 0, len(doc))
+
+	for _, elem := range doc {
+		keys = append(keys, IndexKey{Field: elem.Key, Direction: elem.Value})
+	}
+
+	*k = keys
+
+	return nil
 }
 
 func (k IndexKeys) toBson() bson.D {
 	out := bson.D{}
 
-	for field, value := range k {
-		out = append(out, bson.E{Key: field, Value: value})
+	for _, key := range k {
+		out = append(out, bson.E{Key: key.Field, Value: key.Direction})
 	}
 
 	return out
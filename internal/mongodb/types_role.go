@@ -2,6 +2,8 @@ package mongodb
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -10,9 +12,50 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// Resource is a privilege resource. Exactly one form is used:
+// a database and collection pair, the cluster or any resource.
 type Resource struct {
-	DB         string `bson:"db"         tfsdk:"db"`
-	Collection string `bson:"collection" tfsdk:"collection"`
+	DB          string `bson:"db"          tfsdk:"db"`
+	Collection  string `bson:"collection"  tfsdk:"collection"`
+	Cluster     bool   `bson:"cluster"     tfsdk:"cluster"`
+	AnyResource bool   `bson:"anyResource" tfsdk:"any_resource"`
+}
+
+// String formats the resource as cluster, anyResource or db.collection,
+// where an empty name matches any database or collection.
+func (r Resource) String() string {
+	switch {
+	case r.Cluster:
+		return "cluster"
+	case r.AnyResource:
+		return "anyResource"
+	}
+
+	db, collection := r.DB, r.Collection
+
+	if db == "" {
+		db = "*"
+	}
+
+	if collection == "" {
+		collection = "*"
+	}
+
+	return db + "." + collection
+}
+
+func (r Resource) toBson() bson.D {
+	switch {
+	case r.Cluster:
+		return bson.D{{Key: "cluster", Value: true}}
+	case r.AnyResource:
+		return bson.D{{Key: "anyResource", Value: true}}
+	}
+
+	return bson.D{
+		{Key: "db", Value: r.DB},
+		{Key: "collection", Value: r.Collection},
+	}
 }
 
 type Privilege struct {
@@ -20,8 +63,55 @@ type Privilege struct {
 	Actions  []string `bson:"actions"  tfsdk:"actions"`
 }
 
+func (p Privilege) String() string {
+	return strings.Join(p.Actions, ",") + " on " + p.Resource.String()
+}
+
 type Privileges []Privilege
 
+// Difference returns actions which are not granted by other, grouped by resource.
+func (p Privileges) Difference(other Privileges) Privileges {
+	granted := make(map[Resource][]string, len(other))
+
+	for _, privilege := range other {
+		granted[privilege.Resource] = append(granted[privilege.Resource], privilege.Actions...)
+	}
+
+	out := Privileges{}
+	index := map[Resource]int{}
+
+	for _, privilege := range p {
+		for _, action := range privilege.Actions {
+			if slices.Contains(granted[privilege.Resource], action) {
+				continue
+			}
+
+			i, ok := index[privilege.Resource]
+			if !ok {
+				i = len(out)
+				index[privilege.Resource] = i
+				out = append(out, Privilege{Resource: privilege.Resource})
+			}
+
+			if !slices.Contains(out[i].Actions, action) {
+				out[i].Actions = append(out[i].Actions, action)
+			}
+		}
+	}
+
+	return out
+}
+
+func (p Privileges) Strings() []string {
+	out := make([]string, 0, len(p))
+
+	for _, privilege := range p {
+		out = append(out, privilege.String())
+	}
+
+	return out
+}
+
 func (p *Privileges) ToTerraformSet(ctx context.Context) (*types.Set, diag.Diagnostics) {
 	privileges := make([]basetypes.ObjectValue, 0, len(*p))
 
@@ -52,11 +142,8 @@ func (p *Privileges) toBson() bson.A {
 
 	for _, privilege := range *p {
 		out = append(out, bson.M{
-			"resource": bson.M{
-				"db":         privilege.Resource.DB,
-				"collection": privilege.Resource.Collection,
-			},
-			"actions": privilege.Actions,
+			"resource": privilege.Resource.toBson(),
+			"actions":  privilege.Actions,
 		})
 	}
 
@@ -68,8 +155,35 @@ type ShortRole struct {
 	DB   string `bson:"db"   tfsdk:"db"`
 }
 
+func (r ShortRole) String() string {
+	return r.Role + "@" + r.DB
+}
+
 type ShortRoles []ShortRole
 
+// Difference returns roles which are not present in other.
+func (r ShortRoles) Difference(other ShortRoles) ShortRoles {
+	out := ShortRoles{}
+
+	for _, role := range r {
+		if !slices.Contains(other, role) {
+			out = append(out, role)
+		}
+	}
+
+	return out
+}
+
+func (r ShortRoles) Strings() []string {
+	out := make([]string, 0, len(r))
+
+	for _, role := range r {
+		out = append(out, role.String())
+	}
+
+	return out
+}
+
 func (r *ShortRoles) ToTerraformSet(ctx context.Context) (*types.Set, diag.Diagnostics) {
 	roles := make([]basetypes.ObjectValue, 0, len(*r))
 
@@ -110,6 +224,13 @@ type Role struct {
 	Database   string     `bson:"db"`
 	Privileges Privileges `bson:"privileges"`
 	Roles      ShortRoles `bson:"roles"`
+
+	AuthenticationRestrictions AuthenticationRestrictions `bson:"authenticationRestrictions"`
+
+	// Read-only fields returned by rolesInfo, they can't be set when the role is created
+	IsBuiltin           bool       `bson:"isBuiltin"`
+	InheritedRoles      ShortRoles `bson:"inheritedRoles"`
+	InheritedPrivileges Privileges `bson:"inheritedPrivileges"`
 }
 
 var ShortRoleAttributeTypes = map[string]attr.Type{
@@ -117,12 +238,16 @@ var ShortRoleAttributeTypes = map[string]attr.Type{
 	"db":   types.StringType,
 }
 
+var ResourceAttributeTypes = map[string]attr.Type{
+	"db":           types.StringType,
+	"collection":   types.StringType,
+	"cluster":      types.BoolType,
+	"any_resource": types.BoolType,
+}
+
 var PrivilegeAttributeTypes = map[string]attr.Type{
 	"resource": types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"db":         types.StringType,
-			"collection": types.StringType,
-		},
+		AttrTypes: ResourceAttributeTypes,
 	},
 	"actions": types.SetType{
 		ElemType: types.StringType,
@@ -0,0 +1,96 @@
+package mongodb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+func TestPrivilegesDifference(t *testing.T) {
+	t.Parallel()
+
+	appCollection := mongodb.Resource{DB: "app", Collection: "orders"}
+	appDatabase := mongodb.Resource{DB: "app"}
+	anyDatabase := mongodb.Resource{}
+	cluster := mongodb.Resource{Cluster: true}
+
+	tests := []struct {
+		name  string
+		p     mongodb.Privileges
+		other mongodb.Privileges
+		want  mongodb.Privileges
+	}{
+		{
+			name:  "same privileges",
+			p:     mongodb.Privileges{{Resource: appCollection, Actions: []string{"find", "insert"}}},
+			other: mongodb.Privileges{{Resource: appCollection, Actions: []string{"insert", "find"}}},
+			want:  mongodb.Privileges{},
+		},
+		{
+			name:  "partially overlapping actions on the same resource",
+			p:     mongodb.Privileges{{Resource: appCollection, Actions: []string{"find", "insert", "update"}}},
+			other: mongodb.Privileges{{Resource: appCollection, Actions: []string{"find", "remove"}}},
+			want:  mongodb.Privileges{{Resource: appCollection, Actions: []string{"insert", "update"}}},
+		},
+		{
+			name: "actions split across privileges of the same resource",
+			p:    mongodb.Privileges{{Resource: appCollection, Actions: []string{"find", "insert"}}},
+			other: mongodb.Privileges{
+				{Resource: appCollection, Actions: []string{"find"}},
+				{Resource: appCollection, Actions: []string{"insert"}},
+			},
+			want: mongodb.Privileges{},
+		},
+		{
+			name: "duplicate actions are merged",
+			p: mongodb.Privileges{
+				{Resource: appCollection, Actions: []string{"find"}},
+				{Resource: appCollection, Actions: []string{"find", "insert"}},
+			},
+			other: nil,
+			want:  mongodb.Privileges{{Resource: appCollection, Actions: []string{"find", "insert"}}},
+		},
+		{
+			name:  "cluster is not the same as any database",
+			p:     mongodb.Privileges{{Resource: cluster, Actions: []string{"serverStatus"}}},
+			other: mongodb.Privileges{{Resource: anyDatabase, Actions: []string{"serverStatus"}}},
+			want:  mongodb.Privileges{{Resource: cluster, Actions: []string{"serverStatus"}}},
+		},
+		{
+			name:  "any database is not the same as cluster",
+			p:     mongodb.Privileges{{Resource: anyDatabase, Actions: []string{"find"}}},
+			other: mongodb.Privileges{{Resource: cluster, Actions: []string{"find"}}},
+			want:  mongodb.Privileges{{Resource: anyDatabase, Actions: []string{"find"}}},
+		},
+		{
+			name:  "database and collection resources are different",
+			p:     mongodb.Privileges{{Resource: appDatabase, Actions: []string{"find"}}},
+			other: mongodb.Privileges{{Resource: appCollection, Actions: []string{"find"}}},
+			want:  mongodb.Privileges{{Resource: appDatabase, Actions: []string{"find"}}},
+		},
+		{
+			name: "resources keep their first order",
+			p: mongodb.Privileges{
+				{Resource: cluster, Actions: []string{"serverStatus"}},
+				{Resource: appDatabase, Actions: []string{"find"}},
+			},
+			other: nil,
+			want: mongodb.Privileges{
+				{Resource: cluster, Actions: []string{"serverStatus"}},
+				{Resource: appDatabase, Actions: []string{"find"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.p.Difference(tt.other)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Difference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
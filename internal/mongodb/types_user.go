@@ -1,5 +1,11 @@
 package mongodb
 
+import (
+	"bytes"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
 type User struct {
 	Username string `bson:"user"`
 	Password string
@@ -7,6 +13,17 @@ type User struct {
 	Database   string     `bson:"db"`
 	Roles      ShortRoles `bson:"roles"`
 	Mechanisms []string   `bson:"mechanisms"`
+
+	AuthenticationRestrictions AuthenticationRestrictions `bson:"authenticationRestrictions"`
+	CustomData                 bson.D                     `bson:"customData"`
+}
+
+// customDataEqual compares custom data documents, including key order.
+func (u *User) customDataEqual(other *User) bool {
+	a, errA := bson.Marshal(u.CustomData)
+	b, errB := bson.Marshal(other.CustomData)
+
+	return errA == nil && errB == nil && bytes.Equal(a, b)
 }
 
 type Result struct {
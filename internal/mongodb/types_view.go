@@ -0,0 +1,18 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type ViewOptions struct {
+	ViewOn    string             `bson:"viewOn"`
+	Pipeline  bson.A             `bson:"pipeline"`
+	Collation *options.Collation `bson:"collation,omitempty"`
+}
+
+type View struct {
+	Name     string      `bson:"name"`
+	Database string      `bson:"-"` // Not in MongoDB response
+	Options  ViewOptions `bson:"options"`
+}
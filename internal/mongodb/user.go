@@ -6,40 +6,84 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 const (
-	createUserCmd = "createUser"
-	getUserCmd    = "usersInfo"
-	updateUserCmr = "updateUser"
-	deleteUserCmd = "dropUser"
+	createUserCmd  = "createUser"
+	getUserCmd     = "usersInfo"
+	updateUserCmr  = "updateUser"
+	deleteUserCmd  = "dropUser"
+	grantRolesCmd  = "grantRolesToUser"
+	revokeRolesCmd = "revokeRolesFromUser"
+
+	userAlreadyExistsCode = 51003
 )
 
+// UpsertUser creates the user or updates it in place.
+// Roles of an existing user are changed with grant and revoke commands, so only the difference is applied.
 func (c *Client) UpsertUser(ctx context.Context, user *User) (*User, error) {
+	return withRetry(ctx, c, "UpsertUser", func() (*User, error) {
+		return c.upsertUser(ctx, user)
+	})
+}
+
+func (c *Client) upsertUser(ctx context.Context, user *User) (*User, error) {
 	tflog.Debug(ctx, "UpsertUser", map[string]interface{}{
 		"username": user.Username,
 		"db":       user.Database,
 	})
 
-	var cmd string
-
 	getUserOptions := &GetUserOptions{
 		Username: user.Username,
 		Database: user.Database,
 	}
-	_, err := c.GetUser(ctx, getUserOptions)
+
+	current, err := c.getUser(ctx, getUserOptions)
 
 	switch {
 	case errors.As(err, &NotFoundError{}):
-		cmd = createUserCmd
-	case err == nil:
-		cmd = updateUserCmr
-	default:
+		err = c.createUser(ctx, user)
+
+		var serverErr mongo.ServerError
+		if !errors.As(err, &serverErr) || !serverErr.HasErrorCode(userAlreadyExistsCode) {
+			if err != nil {
+				return nil, err
+			}
+
+			return c.getUser(ctx, getUserOptions)
+		}
+
+		// The user was created concurrently, update it instead
+		tflog.Debug(ctx, "user already exists, updating it", map[string]interface{}{
+			"username": user.Username,
+			"db":       user.Database,
+		})
+
+		current, err = c.getUser(ctx, getUserOptions)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	err = c.updateUser(ctx, user, current)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.updateUserRoles(ctx, user, current.Roles)
+	if err != nil {
 		return nil, err
 	}
 
+	return c.getUser(ctx, getUserOptions)
+}
+
+func (c *Client) createUser(ctx context.Context, user *User) error {
 	command := bson.D{
-		{Key: cmd, Value: user.Username},
+		{Key: createUserCmd, Value: user.Username},
 		// Roles field is required, but empty array is fine
 		{Key: "roles", Value: user.Roles.toBson()},
 	}
@@ -52,30 +96,94 @@ func (c *Client) UpsertUser(ctx context.Context, user *User) (*User, error) {
 		command = append(command, bson.E{Key: "mechanisms", Value: user.Mechanisms})
 	}
 
-	response := c.mongo.Database(user.Database).RunCommand(ctx, command)
+	if len(user.AuthenticationRestrictions) > 0 {
+		command = append(command, bson.E{
+			Key:   "authenticationRestrictions",
+			Value: user.AuthenticationRestrictions.toBson(),
+		})
+	}
 
-	err = response.Err()
-	if err != nil {
-		return nil, err
+	if len(user.CustomData) > 0 {
+		command = append(command, bson.E{Key: "customData", Value: user.CustomData})
 	}
 
-	result := &Result{}
+	return c.runCommand(ctx, user.Database, command)
+}
 
-	err = response.Decode(result)
-	if err != nil {
-		return nil, err
+// updateUser updates everything except roles, which are handled by updateUserRoles.
+// Authentication restrictions and custom data are only sent when they differ from the current ones.
+func (c *Client) updateUser(ctx context.Context, user *User, current *User) error {
+	command := bson.D{
+		{Key: updateUserCmr, Value: user.Username},
 	}
 
-	if result.Ok != 1 {
-		return nil, FailedCommandError{cmd}
+	if user.Password != "" {
+		command = append(command, bson.E{Key: "pwd", Value: user.Password})
 	}
 
-	user, err = c.GetUser(ctx, getUserOptions)
-	if err != nil {
-		return nil, err
+	if len(user.Mechanisms) > 0 {
+		command = append(command, bson.E{Key: "mechanisms", Value: user.Mechanisms})
+	}
+
+	if !user.AuthenticationRestrictions.Equal(current.AuthenticationRestrictions) {
+		command = append(command, bson.E{
+			Key:   "authenticationRestrictions",
+			Value: user.AuthenticationRestrictions.toBson(),
+		})
 	}
 
-	return user, nil
+	if !user.customDataEqual(current) {
+		customData := user.CustomData
+		// An empty document removes custom data
+		if customData == nil {
+			customData = bson.D{}
+		}
+
+		command = append(command, bson.E{Key: "customData", Value: customData})
+	}
+
+	// Nothing to update
+	if len(command) == 1 {
+		return nil
+	}
+
+	return c.runCommand(ctx, user.Database, command)
+}
+
+// updateUserRoles grants missing roles and then revokes extra ones.
+// If a command fails, the returned RolesChangeError lists roles which were already changed.
+func (c *Client) updateUserRoles(ctx context.Context, user *User, current ShortRoles) error {
+	granted := user.Roles.Difference(current)
+	revoked := current.Difference(user.Roles)
+
+	tflog.Debug(ctx, "UpdateUserRoles", map[string]interface{}{
+		"username": user.Username,
+		"db":       user.Database,
+		"granted":  granted.Strings(),
+		"revoked":  revoked.Strings(),
+	})
+
+	if len(granted) > 0 {
+		err := c.runCommand(ctx, user.Database, bson.D{
+			{Key: grantRolesCmd, Value: user.Username},
+			{Key: "roles", Value: granted.toBson()},
+		})
+		if err != nil {
+			return RolesChangeError{Err: err}
+		}
+	}
+
+	if len(revoked) > 0 {
+		err := c.runCommand(ctx, user.Database, bson.D{
+			{Key: revokeRolesCmd, Value: user.Username},
+			{Key: "roles", Value: revoked.toBson()},
+		})
+		if err != nil {
+			return RolesChangeError{Granted: granted.Strings(), Err: err}
+		}
+	}
+
+	return nil
 }
 
 type GetUserOptions struct {
@@ -89,6 +197,12 @@ type getUsersResult struct {
 }
 
 func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, error) {
+	return withRetry(ctx, c, "GetUser", func() (*User, error) {
+		return c.getUser(ctx, options)
+	})
+}
+
+func (c *Client) getUser(ctx context.Context, options *GetUserOptions) (*User, error) {
 	tflog.Debug(ctx, "GetUser", map[string]interface{}{
 		"username": options.Username,
 		"db":       options.Database,
@@ -96,11 +210,12 @@ func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, e
 
 	command := bson.D{
 		{Key: getUserCmd, Value: options.Username},
+		{Key: "showAuthenticationRestrictions", Value: true},
 	}
 
 	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
 	if err := response.Err(); err != nil {
-		return nil, err
+		return nil, commandError(getUserCmd, err)
 	}
 
 	var result getUsersResult
@@ -111,14 +226,14 @@ func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, e
 	}
 
 	if result.Ok != 1 {
-		return nil, FailedCommandError{getUserCmd}
+		return nil, FailedCommandError{Cmd: getUserCmd}
 	}
 
 	userCount := len(result.Users)
 
 	switch {
 	case userCount == 0:
-		return nil, NotFoundError{}
+		return nil, NotFoundError{options.Username, "user"}
 	case userCount > 1:
 		return nil, TooManyError{t: "user"}
 	}
@@ -126,6 +241,69 @@ func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, e
 	return &result.Users[0], nil
 }
 
+// ListUsersOptions selects users to list. Users of all databases are listed if Database is empty.
+type ListUsersOptions struct {
+	Database string
+	// Role limits users to the ones granted the role directly. Inherited roles are only reported by usersInfo
+	// with showPrivileges, which can't be used with forAllDBs, so they are not matched.
+	Role *ShortRole
+}
+
+func (c *Client) ListUsers(ctx context.Context, options *ListUsersOptions) ([]User, error) {
+	return withRetry(ctx, c, "ListUsers", func() ([]User, error) {
+		return c.listUsers(ctx, options)
+	})
+}
+
+func (c *Client) listUsers(ctx context.Context, options *ListUsersOptions) ([]User, error) {
+	tflog.Debug(ctx, "ListUsers", map[string]interface{}{
+		"db": options.Database,
+	})
+
+	database := options.Database
+
+	var usersInfo any = 1
+
+	if database == "" {
+		database = adminDatabase
+		usersInfo = bson.D{{Key: "forAllDBs", Value: true}}
+	}
+
+	command := bson.D{
+		{Key: getUserCmd, Value: usersInfo},
+		{Key: "showAuthenticationRestrictions", Value: true},
+	}
+
+	if options.Role != nil {
+		command = append(command, bson.E{Key: "filter", Value: bson.D{
+			{Key: "roles", Value: bson.D{
+				{Key: "$elemMatch", Value: bson.D{
+					{Key: "role", Value: options.Role.Role},
+					{Key: "db", Value: options.Role.DB},
+				}},
+			}},
+		}})
+	}
+
+	response := c.mongo.Database(database).RunCommand(ctx, command)
+	if err := response.Err(); err != nil {
+		return nil, commandError(getUserCmd, err)
+	}
+
+	var result getUsersResult
+
+	err := response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Ok != 1 {
+		return nil, FailedCommandError{Cmd: getUserCmd}
+	}
+
+	return result.Users, nil
+}
+
 type DeleteUserOptions struct {
 	Username string
 	Database string
@@ -143,7 +321,7 @@ func (c *Client) DeleteUser(ctx context.Context, options *DeleteUserOptions) err
 
 	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
 	if err := response.Err(); err != nil {
-		return err
+		return commandError(deleteUserCmd, err)
 	}
 
 	result := Result{}
@@ -154,7 +332,7 @@ func (c *Client) DeleteUser(ctx context.Context, options *DeleteUserOptions) err
 	}
 
 	if result.Ok != 1 {
-		return FailedCommandError{deleteUserCmd}
+		return FailedCommandError{Cmd: deleteUserCmd}
 	}
 
 	return nil
@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	viewType = "view"
+)
+
+// CreateView isn't retried, a failed attempt may have created the view already.
+func (c *Client) CreateView(ctx context.Context, view *View) (*View, error) {
+	tflog.Debug(ctx, "CreateView", map[string]any{
+		"database": view.Database,
+		"name":     view.Name,
+		"view_on":  view.Options.ViewOn,
+	})
+
+	opts := options.CreateView()
+	if view.Options.Collation != nil {
+		opts.SetCollation(view.Options.Collation)
+	}
+
+	err := c.mongo.Database(view.Database).CreateView(ctx, view.Name, view.Options.ViewOn, view.Options.Pipeline, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error creating view: %w", commandError(createCollectionCmd, err))
+	}
+
+	return c.GetView(ctx, &GetViewOptions{
+		Name:     view.Name,
+		Database: view.Database,
+	})
+}
+
+type GetViewOptions struct {
+	Name     string
+	Database string
+}
+
+func (c *Client) GetView(ctx context.Context, options *GetViewOptions) (*View, error) {
+	return withRetry(ctx, c, "GetView", func() (*View, error) {
+		return c.getView(ctx, options)
+	})
+}
+
+func (c *Client) getView(ctx context.Context, options *GetViewOptions) (*View, error) {
+	tflog.Debug(ctx, "GetView", map[string]any{
+		"database": options.Database,
+		"name":     options.Name,
+	})
+
+	var views []View
+
+	err := c.listCollections(ctx, options.Database, bson.D{
+		{Key: "name", Value: options.Name},
+		{Key: "type", Value: viewType},
+	}, &views)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(views) == 0:
+		return nil, NotFoundError{options.Name, "view"}
+	case len(views) > 1:
+		return nil, TooManyError{"view"}
+	}
+
+	views[0].Database = options.Database
+
+	return &views[0], nil
+}
+
+// ModifyView replaces the source and the pipeline of the view. Collation can't be changed in place.
+func (c *Client) ModifyView(ctx context.Context, view *View) (*View, error) {
+	return withRetry(ctx, c, "ModifyView", func() (*View, error) {
+		return c.modifyView(ctx, view)
+	})
+}
+
+func (c *Client) modifyView(ctx context.Context, view *View) (*View, error) {
+	tflog.Debug(ctx, "ModifyView", map[string]any{
+		"database": view.Database,
+		"name":     view.Name,
+		"view_on":  view.Options.ViewOn,
+	})
+
+	command := bson.D{
+		{Key: modifyCollectionCmd, Value: view.Name},
+		{Key: "viewOn", Value: view.Options.ViewOn},
+		{Key: "pipeline", Value: view.Options.Pipeline},
+	}
+
+	response := c.mongo.Database(view.Database).RunCommand(ctx, command)
+
+	err := response.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error modifying view: %w", commandError(modifyCollectionCmd, err))
+	}
+
+	var result Result
+
+	err = response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Ok != 1 {
+		return nil, FailedCommandError{Cmd: modifyCollectionCmd}
+	}
+
+	return c.getView(ctx, &GetViewOptions{
+		Name:     view.Name,
+		Database: view.Database,
+	})
+}
+
+func (c *Client) DropView(ctx context.Context, options *GetViewOptions) error {
+	tflog.Debug(ctx, "DropView", map[string]any{
+		"database": options.Database,
+		"name":     options.Name,
+	})
+
+	_, err := withRetry(ctx, c, "DropView", func() (struct{}, error) {
+		err := c.mongo.Database(options.Database).Collection(options.Name).Drop(ctx)
+
+		return struct{}{}, commandError(dropCollectionCmd, err)
+	})
+
+	return err
+}
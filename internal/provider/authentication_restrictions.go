@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+type AuthenticationRestrictionModel struct {
+	ClientSource  types.Set `tfsdk:"client_source"`
+	ServerAddress types.Set `tfsdk:"server_address"`
+}
+
+func (a AuthenticationRestrictionModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"client_source":  types.SetType{ElemType: types.StringType},
+		"server_address": types.SetType{ElemType: types.StringType},
+	}
+}
+
+// authenticationRestrictionsSchema is shared by users and roles. Restrictions are changed in place.
+func authenticationRestrictionsSchema() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Addresses users can authenticate from and to. " +
+			"A connection is allowed if it matches any of the restrictions",
+		Optional: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"client_source": schema.SetAttribute{
+					MarkdownDescription: "IP addresses or CIDR ranges the client must connect from",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.Set{
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ValueStringsAre(cidrValidator{}),
+					},
+				},
+				"server_address": schema.SetAttribute{
+					MarkdownDescription: "IP addresses or CIDR ranges the client must connect to",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.Set{
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ValueStringsAre(cidrValidator{}),
+					},
+				},
+			},
+			Validators: []validator.Object{
+				restrictionNotEmptyValidator{},
+			},
+		},
+	}
+}
+
+// authenticationRestrictionsDataSourceSchema is the read-only version of authenticationRestrictionsSchema.
+func authenticationRestrictionsDataSourceSchema() datasourceschema.SetNestedAttribute {
+	return datasourceschema.SetNestedAttribute{
+		MarkdownDescription: "Addresses the user can authenticate from and to, null if there are no restrictions",
+		Computed:            true,
+		NestedObject: datasourceschema.NestedAttributeObject{
+			Attributes: map[string]datasourceschema.Attribute{
+				"client_source": datasourceschema.SetAttribute{
+					MarkdownDescription: "IP addresses or CIDR ranges the client must connect from",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+				"server_address": datasourceschema.SetAttribute{
+					MarkdownDescription: "IP addresses or CIDR ranges the client must connect to",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// newAuthenticationRestrictionsSet keeps the current null value when the server reports no restrictions.
+func newAuthenticationRestrictionsSet(
+	ctx context.Context,
+	current types.Set,
+	restrictions mongodb.AuthenticationRestrictions,
+) (types.Set, diag.Diagnostics) {
+	restrictionType := types.ObjectType{AttrTypes: AuthenticationRestrictionModel{}.AttributeTypes()}
+
+	if len(restrictions) == 0 && current.IsNull() {
+		return types.SetNull(restrictionType), nil
+	}
+
+	diags := diag.Diagnostics{}
+	models := make([]AuthenticationRestrictionModel, 0, len(restrictions))
+
+	for _, restriction := range restrictions {
+		model := AuthenticationRestrictionModel{
+			ClientSource:  types.SetNull(types.StringType),
+			ServerAddress: types.SetNull(types.StringType),
+		}
+
+		var d diag.Diagnostics
+
+		if len(restriction.ClientSource) > 0 {
+			model.ClientSource, d = types.SetValueFrom(ctx, types.StringType, restriction.ClientSource)
+			diags.Append(d...)
+		}
+
+		if len(restriction.ServerAddress) > 0 {
+			model.ServerAddress, d = types.SetValueFrom(ctx, types.StringType, restriction.ServerAddress)
+			diags.Append(d...)
+		}
+
+		models = append(models, model)
+	}
+
+	if diags.HasError() {
+		return types.SetNull(restrictionType), diags
+	}
+
+	set, d := types.SetValueFrom(ctx, restrictionType, models)
+	diags.Append(d...)
+
+	return set, diags
+}
+
+func getAuthenticationRestrictions(
+	ctx context.Context,
+	set types.Set,
+	ptr *mongodb.AuthenticationRestrictions,
+) diag.Diagnostics {
+	if set.IsNull() || set.IsUnknown() {
+		*ptr = nil
+
+		return nil
+	}
+
+	var models []AuthenticationRestrictionModel
+
+	diags := set.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	restrictions := make(mongodb.AuthenticationRestrictions, 0, len(models))
+
+	for _, model := range models {
+		var restriction mongodb.AuthenticationRestriction
+
+		if !model.ClientSource.IsNull() {
+			diags.Append(model.ClientSource.ElementsAs(ctx, &restriction.ClientSource, false)...)
+		}
+
+		if !model.ServerAddress.IsNull() {
+			diags.Append(model.ServerAddress.ElementsAs(ctx, &restriction.ServerAddress, false)...)
+		}
+
+		restrictions = append(restrictions, restriction)
+	}
+
+	*ptr = restrictions
+
+	return diags
+}
+
+var _ validator.String = cidrValidator{}
+
+// cidrValidator accepts an IP address or a CIDR range.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be an IP address or a CIDR range"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return
+	}
+
+	if _, err := netip.ParseAddr(value); err == nil {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid address",
+		fmt.Sprintf("%q is neither an IP address nor a CIDR range", value),
+	)
+}
+
+var _ validator.Object = restrictionNotEmptyValidator{}
+
+// restrictionNotEmptyValidator requires at least one of client_source and server_address.
+type restrictionNotEmptyValidator struct{}
+
+func (v restrictionNotEmptyValidator) Description(_ context.Context) string {
+	return "at least one of client_source and server_address must be set"
+}
+
+func (v restrictionNotEmptyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v restrictionNotEmptyValidator) ValidateObject(
+	_ context.Context,
+	req validator.ObjectRequest,
+	resp *validator.ObjectResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range req.ConfigValue.Attributes() {
+		if !value.IsNull() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid authentication restriction",
+		"At least one of client_source and server_address must be set",
+	)
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// connectClient connects on the first use, so a connection error is reported by the resource which needed it.
+func connectClient(ctx context.Context, client *mongodb.Client, diags *diag.Diagnostics) bool {
+	if client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. The provider configuration may depend on values "+
+				"which are not known yet. Otherwise, please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	err := client.Connect(ctx)
+	if err != nil {
+		diags.AddError(
+			"Failed to connect to MongoDB",
+			err.Error(),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// deferRead keeps the prior state while the provider configuration is unknown,
+// e.g. during a plan which creates the MongoDB server.
+func deferRead(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &resource.Deferred{Reason: resource.DeferredReasonProviderConfigUnknown}
+
+		return
+	}
+
+	tflog.Warn(ctx, "provider configuration is unknown, keeping the prior state")
+}
+
+// checkDataSourceClient defers data sources while the provider configuration is unknown, if Terraform allows it.
+// Otherwise, the data source can't be read without a client.
+func checkDataSourceClient(
+	ctx context.Context,
+	client *mongodb.Client,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) bool {
+	if client == nil && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &datasource.Deferred{Reason: datasource.DeferredReasonProviderConfigUnknown}
+
+		return false
+	}
+
+	return connectClient(ctx, client, &resp.Diagnostics)
+}
+
+// serverRequirement describes an attribute which isn't supported by every server.
+type serverRequirement struct {
+	attribute string
+	// minMajor and minMinor are the first MongoDB version supporting the attribute
+	minMajor int
+	minMinor int
+	// unsupported flavors don't implement the attribute in any version
+	unsupported []mongodb.Flavor
+}
+
+// checkServerRequirements rejects attributes the server doesn't support at plan time instead of failing
+// in the middle of an apply. The check is skipped if the server can't be reached yet, the apply reports that.
+// Values are read from the configuration, as defaults in the plan can't be told apart from explicit values.
+func checkServerRequirements(
+	ctx context.Context,
+	client *mongodb.Client,
+	plan tfsdk.Plan,
+	config tfsdk.Config,
+	requirements []serverRequirement,
+	diags *diag.Diagnostics,
+) {
+	// Destroy plans don't set anything
+	if client == nil || plan.Raw.IsNull() {
+		return
+	}
+
+	err := client.Connect(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "skipping server requirements, server is not reachable", map[string]any{
+			"err": err,
+		})
+
+		return
+	}
+
+	server := client.Server()
+	if server == nil {
+		return
+	}
+
+	for _, requirement := range requirements {
+		attribute := path.Root(requirement.attribute)
+
+		var value attr.Value
+
+		diags.Append(config.GetAttribute(ctx, attribute, &value)...)
+
+		if !isAttributeSet(value) {
+			continue
+		}
+
+		switch {
+		case slices.Contains(requirement.unsupported, server.Flavor):
+			diags.AddAttributeError(
+				attribute,
+				"Unsupported attribute",
+				fmt.Sprintf("%s is not supported by %s servers.", requirement.attribute, server.Flavor),
+			)
+		case !server.VersionAtLeast(requirement.minMajor, requirement.minMinor):
+			diags.AddAttributeError(
+				attribute,
+				"Unsupported attribute",
+				fmt.Sprintf(
+					"%s requires MongoDB %d.%d or later, the server version is %s.",
+					requirement.attribute,
+					requirement.minMajor,
+					requirement.minMinor,
+					server.Version,
+				),
+			)
+		}
+	}
+}
+
+// isAttributeSet reports configured values, including an explicit false.
+// Unknown values are skipped, the apply reports them if they turn out unsupported.
+func isAttributeSet(value attr.Value) bool {
+	return value != nil && !value.IsNull() && !value.IsUnknown()
+}
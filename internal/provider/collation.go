@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CollationModel struct {
+	Locale          types.String `tfsdk:"locale"`
+	CaseLevel       types.Bool   `tfsdk:"case_level"`
+	CaseFirst       types.String `tfsdk:"case_first"`
+	Strength        types.Int64  `tfsdk:"strength"`
+	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
+	Alternate       types.String `tfsdk:"alternate"`
+	MaxVariable     types.String `tfsdk:"max_variable"`
+	Backwards       types.Bool   `tfsdk:"backwards"`
+}
+
+func (c CollationModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"locale":           types.StringType,
+		"case_level":       types.BoolType,
+		"case_first":       types.StringType,
+		"strength":         types.Int64Type,
+		"numeric_ordering": types.BoolType,
+		"alternate":        types.StringType,
+		"max_variable":     types.StringType,
+		"backwards":        types.BoolType,
+	}
+}
+
+// collationSchema is shared by resources supporting collation. Collation can't be changed in place.
+func collationSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Collation settings for string comparison",
+		Optional:    true,
+		Computed:    true,
+		Default:     objectdefault.StaticValue(types.ObjectNull(CollationModel{}.AttributeTypes())),
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Description: "The locale for string comparison",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"case_level": schema.BoolAttribute{
+				Description: "Whether to consider case in the 'Level=1' comparison",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"case_first": schema.StringAttribute{
+				Description: "Whether uppercase or lowercase should sort first",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("off"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("upper", "lower", "off"),
+				},
+			},
+			"strength": schema.Int64Attribute{
+				Description: "Comparison level (1-5)",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 5),
+				},
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Description: "Whether to compare numeric strings as numbers",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"alternate": schema.StringAttribute{
+				Description: "Whether spaces and punctuation are considered base characters",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("non-ignorable"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("non-ignorable", "shifted"),
+				},
+			},
+			"max_variable": schema.StringAttribute{
+				Description: "Which characters are affected by 'alternate'",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("punct"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("punct", "space"),
+				},
+			},
+			"backwards": schema.BoolAttribute{
+				Description: "Whether to reverse secondary differences",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func newCollationObject(ctx context.Context, collation *options.Collation) (types.Object, diag.Diagnostics) {
+	if collation == nil {
+		return types.ObjectNull(CollationModel{}.AttributeTypes()), nil
+	}
+
+	model := CollationModel{
+		Locale:          types.StringValue(collation.Locale),
+		CaseLevel:       types.BoolValue(collation.CaseLevel),
+		CaseFirst:       types.StringValue(collation.CaseFirst),
+		Strength:        types.Int64Value(int64(collation.Strength)),
+		NumericOrdering: types.BoolValue(collation.NumericOrdering),
+		Alternate:       types.StringValue(collation.Alternate),
+		MaxVariable:     types.StringValue(collation.MaxVariable),
+		Backwards:       types.BoolValue(collation.Backwards),
+	}
+
+	return types.ObjectValueFrom(ctx, model.AttributeTypes(), model)
+}
+
+func getCollation(ctx context.Context, object types.Object, ptr **options.Collation) diag.Diagnostics {
+	collation := &CollationModel{}
+
+	diags := object.As(ctx, collation, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return diags
+	}
+
+	*ptr = &options.Collation{
+		Locale:          collation.Locale.ValueString(),
+		CaseLevel:       collation.CaseLevel.ValueBool(),
+		CaseFirst:       collation.CaseFirst.ValueString(),
+		Strength:        int(collation.Strength.ValueInt64()),
+		NumericOrdering: collation.NumericOrdering.ValueBool(),
+		Alternate:       collation.Alternate.ValueString(),
+		MaxVariable:     collation.MaxVariable.ValueString(),
+		Backwards:       collation.Backwards.ValueBool(),
+	}
+
+	return diags
+}
@@ -0,0 +1,804 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+const (
+	defaultValidationLevel  = "strict"
+	defaultValidationAction = "error"
+)
+
+var (
+	_ resource.Resource                   = &CollectionResource{}
+	_ resource.ResourceWithConfigure      = &CollectionResource{}
+	_ resource.ResourceWithImportState    = &CollectionResource{}
+	_ resource.ResourceWithValidateConfig = &CollectionResource{}
+	_ resource.ResourceWithModifyPlan     = &CollectionResource{}
+)
+
+var collectionErrorAttributes = errorAttributes{
+	mongodb.NamespaceExistsCode: path.Root("name"),
+}
+
+// collectionServerRequirements are collection options which older or compatible servers don't support.
+var collectionServerRequirements = []serverRequirement{
+	{attribute: "time_series", minMajor: 5, unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB}},
+	{attribute: "clustered_index", minMajor: 5, minMinor: 3, unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB}},
+	{
+		attribute:   "change_stream_pre_and_post_images",
+		minMajor:    6,
+		unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB, mongodb.FlavorCosmosDB},
+	},
+	{attribute: "collation", unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB}},
+}
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{}
+}
+
+type CollectionResource struct {
+	client *mongodb.Client
+}
+
+type ClusteredIndexModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (c ClusteredIndexModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name": types.StringType,
+	}
+}
+
+type TimeSeriesModel struct {
+	TimeField   types.String `tfsdk:"time_field"`
+	MetaField   types.String `tfsdk:"meta_field"`
+	Granularity types.String `tfsdk:"granularity"`
+}
+
+func (t TimeSeriesModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"time_field":  types.StringType,
+		"meta_field":  types.StringType,
+		"granularity": types.StringType,
+	}
+}
+
+type CollectionResourceModel struct {
+	Database                     types.String `tfsdk:"database"`
+	Name                         types.String `tfsdk:"name"`
+	Capped                       types.Bool   `tfsdk:"capped"`
+	Size                         types.Int64  `tfsdk:"size"`
+	Max                          types.Int64  `tfsdk:"max"`
+	ClusteredIndex               types.Object `tfsdk:"clustered_index"`
+	TimeSeries                   types.Object `tfsdk:"time_series"`
+	ExpireAfterSeconds           types.Int64  `tfsdk:"expire_after_seconds"`
+	ChangeStreamPreAndPostImages types.Bool   `tfsdk:"change_stream_pre_and_post_images"`
+	Collation                    types.Object `tfsdk:"collation"`
+	Validator                    types.String `tfsdk:"validator"`
+	ValidationLevel              types.String `tfsdk:"validation_level"`
+	ValidationAction             types.String `tfsdk:"validation_action"`
+}
+
+func (c *CollectionResourceModel) updateState(ctx context.Context, collection *mongodb.Collection) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	c.Database = types.StringValue(collection.Database)
+	c.Name = types.StringValue(collection.Name)
+
+	c.Capped = types.BoolValue(collection.Options.Capped != nil && *collection.Options.Capped)
+	c.Max = types.Int64PointerValue(collection.Options.Max)
+
+	// MongoDB rounds up the size of capped collections, keep the configured value if it matches
+	size := collection.Options.Size
+	if size == nil || c.Size.IsNull() || c.Size.IsUnknown() || mongodb.CappedSize(c.Size.ValueInt64()) != *size {
+		c.Size = types.Int64PointerValue(size)
+	}
+
+	// Parse clustered index
+	if collection.Options.ClusteredIndex.IsSet() {
+		clusteredIndex := ClusteredIndexModel{
+			Name: types.StringPointerValue(collection.Options.ClusteredIndex.Name),
+		}
+
+		var d diag.Diagnostics
+
+		c.ClusteredIndex, d = types.ObjectValueFrom(ctx, clusteredIndex.AttributeTypes(), clusteredIndex)
+
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+	} else {
+		c.ClusteredIndex = types.ObjectNull(ClusteredIndexModel{}.AttributeTypes())
+	}
+
+	// Parse time series
+	if collection.Options.TimeSeries != nil {
+		timeSeries := TimeSeriesModel{
+			TimeField:   types.StringValue(collection.Options.TimeSeries.TimeField),
+			MetaField:   types.StringPointerValue(collection.Options.TimeSeries.MetaField),
+			Granularity: types.StringPointerValue(collection.Options.TimeSeries.Granularity),
+		}
+
+		var d diag.Diagnostics
+
+		c.TimeSeries, d = types.ObjectValueFrom(ctx, timeSeries.AttributeTypes(), timeSeries)
+
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+	} else {
+		c.TimeSeries = types.ObjectNull(TimeSeriesModel{}.AttributeTypes())
+	}
+
+	c.ExpireAfterSeconds = types.Int64PointerValue(collection.Options.ExpireAfterSeconds)
+	c.ChangeStreamPreAndPostImages = types.BoolValue(collection.Options.ChangeStreamPreAndPostImages != nil &&
+		collection.Options.ChangeStreamPreAndPostImages.Enabled)
+
+	// Parse collation
+	collation, d := newCollationObject(ctx, collection.Options.Collation)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	c.Collation = collation
+
+	// Parse validator
+	if len(collection.Options.Validator) > 0 {
+		validator, err := marshalExtendedJSON(collection.Options.Validator)
+		if err != nil {
+			diags.AddError("Failed to parse validator", err.Error())
+
+			return diags
+		}
+
+		c.Validator = semanticJSONValue(c.Validator, validator, collection.Options.Validator)
+	} else {
+		c.Validator = types.StringNull()
+	}
+
+	c.ValidationLevel = types.StringValue(defaultValidationLevel)
+	if collection.Options.ValidationLevel != nil {
+		c.ValidationLevel = types.StringPointerValue(collection.Options.ValidationLevel)
+	}
+
+	c.ValidationAction = types.StringValue(defaultValidationAction)
+	if collection.Options.ValidationAction != nil {
+		c.ValidationAction = types.StringPointerValue(collection.Options.ValidationAction)
+	}
+
+	return diags
+}
+
+func (r *CollectionResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages MongoDB collections",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Collection name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"capped": schema.BoolAttribute{
+				Description: "Whether the collection is capped. Requires size",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "Maximum size in bytes of a capped collection. Changed in place",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max": schema.Int64Attribute{
+				Description: "Maximum number of documents in a capped collection. Changed in place",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"clustered_index": schema.SingleNestedAttribute{
+				Description: "Creates a clustered collection ordered by _id",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "Clustered index name",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"time_series": schema.SingleNestedAttribute{
+				Description: "Creates a time series collection",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						func(
+							_ context.Context,
+							req planmodifier.ObjectRequest,
+							resp *objectplanmodifier.RequiresReplaceIfFuncResponse,
+						) {
+							// Only granularity can be changed in place
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"Converting to or from a time series collection requires the collection to be recreated",
+						"Converting to or from a time series collection requires the collection to be recreated",
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"time_field": schema.StringAttribute{
+						Description: "Name of the field which contains the date in each document",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"meta_field": schema.StringAttribute{
+						Description: "Name of the field which contains metadata in each document",
+						Optional:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"granularity": schema.StringAttribute{
+						Description: "Granularity of the time series data. " +
+							"Changed in place, but can only be increased",
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+						Validators: []validator.String{
+							stringvalidator.OneOf("seconds", "minutes", "hours"),
+						},
+					},
+				},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Description: "TTL in seconds for documents of time series and clustered collections. Changed in place",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"change_stream_pre_and_post_images": schema.BoolAttribute{
+				Description: "Whether change streams can output the document before and after an update. " +
+					"Changed in place",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"collation": collationSchema(),
+			"validator": schema.StringAttribute{
+				Description: "JSON (or Extended JSON) encoded validation rules, " +
+					"e.g. a $jsonSchema document. Changed in place",
+				Optional: true,
+			},
+			"validation_level": schema.StringAttribute{
+				Description: fmt.Sprintf("How strictly validation rules are applied to existing documents "+
+					"during updates. %q is used by default. Changed in place", defaultValidationLevel),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultValidationLevel),
+				Validators: []validator.String{
+					stringvalidator.OneOf("strict", "moderate", "off"),
+				},
+			},
+			"validation_action": schema.StringAttribute{
+				Description: fmt.Sprintf("Whether invalid documents are rejected or only logged. "+
+					"%q is used by default. Changed in place", defaultValidationAction),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultValidationAction),
+				Validators: []validator.String{
+					stringvalidator.OneOf("error", "warn", "errorAndLog"),
+				},
+			},
+		},
+	}
+}
+
+func (r *CollectionResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Validator.IsNull() && !config.Validator.IsUnknown() {
+		var validator bson.D
+
+		err := parseExtendedJSON(config.Validator.ValueString(), &validator)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validator"),
+				"Failed to parse validator json",
+				err.Error(),
+			)
+		}
+	}
+
+	if config.Capped.IsUnknown() || config.TimeSeries.IsUnknown() || config.ClusteredIndex.IsUnknown() {
+		return
+	}
+
+	capped := config.Capped.ValueBool()
+	timeSeries := !config.TimeSeries.IsNull()
+	clustered := !config.ClusteredIndex.IsNull()
+
+	if capped && config.Size.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size"),
+			"Invalid Capped Collection Configuration",
+			"size is required for capped collections",
+		)
+	}
+
+	if !capped && (!config.Size.IsNull() || !config.Max.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("capped"),
+			"Invalid Capped Collection Configuration",
+			"size and max can only be used with capped collections",
+		)
+	}
+
+	if capped && (timeSeries || clustered) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("capped"),
+			"Invalid Collection Configuration",
+			"Capped collections can't be time series or clustered collections",
+		)
+	}
+
+	if timeSeries && clustered {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("clustered_index"),
+			"Invalid Collection Configuration",
+			"Time series collections are clustered implicitly, clustered_index can't be set",
+		)
+	}
+
+	if !config.ExpireAfterSeconds.IsNull() && !timeSeries && !clustered {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expire_after_seconds"),
+			"Invalid TTL Configuration",
+			"expire_after_seconds can only be used with time series or clustered collections",
+		)
+	}
+}
+
+func (r *CollectionResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *CollectionResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkServerRequirements(ctx, r.client, req.Plan, req.Config, collectionServerRequirements, &resp.Diagnostics)
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection := &mongodb.Collection{
+		Database: plan.Database.ValueString(),
+		Name:     plan.Name.ValueString(),
+
+		Options: mongodb.CollectionOptions{
+			Size:               plan.Size.ValueInt64Pointer(),
+			Max:                plan.Max.ValueInt64Pointer(),
+			ExpireAfterSeconds: plan.ExpireAfterSeconds.ValueInt64Pointer(),
+		},
+	}
+
+	if plan.Capped.ValueBool() {
+		collection.Options.Capped = plan.Capped.ValueBoolPointer()
+	}
+
+	if plan.ChangeStreamPreAndPostImages.ValueBool() {
+		collection.Options.ChangeStreamPreAndPostImages = &mongodb.ChangeStreamPreAndPostImages{
+			Enabled: true,
+		}
+	}
+
+	// Parse clustered index
+	if !plan.ClusteredIndex.IsNull() && !plan.ClusteredIndex.IsUnknown() {
+		clusteredIndex := &ClusteredIndexModel{}
+		resp.Diagnostics.Append(plan.ClusteredIndex.As(ctx, clusteredIndex, basetypes.ObjectAsOptions{})...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		collection.Options.ClusteredIndex = &mongodb.ClusteredIndex{
+			Key:    bson.D{{Key: "_id", Value: 1}},
+			Unique: true,
+			Name:   clusteredIndex.Name.ValueStringPointer(),
+		}
+	}
+
+	// Parse time series
+	if !plan.TimeSeries.IsNull() && !plan.TimeSeries.IsUnknown() {
+		timeSeries := &TimeSeriesModel{}
+		resp.Diagnostics.Append(plan.TimeSeries.As(ctx, timeSeries, basetypes.ObjectAsOptions{})...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		collection.Options.TimeSeries = &mongodb.TimeSeriesOptions{
+			TimeField:   timeSeries.TimeField.ValueString(),
+			MetaField:   timeSeries.MetaField.ValueStringPointer(),
+			Granularity: timeSeries.Granularity.ValueStringPointer(),
+		}
+	}
+
+	// Parse collation
+	if !plan.Collation.IsNull() && !plan.Collation.IsUnknown() {
+		resp.Diagnostics.Append(getCollation(ctx, plan.Collation, &collection.Options.Collation)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Parse validator
+	if !plan.Validator.IsNull() && !plan.Validator.IsUnknown() {
+		err := parseExtendedJSON(plan.Validator.ValueString(), &collection.Options.Validator)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse validator json", err.Error())
+
+			return
+		}
+	}
+
+	// Validation settings are only sent when they matter, so MongoDB doesn't reject them for special collections
+	if len(collection.Options.Validator) > 0 || plan.ValidationLevel.ValueString() != defaultValidationLevel {
+		collection.Options.ValidationLevel = plan.ValidationLevel.ValueStringPointer()
+	}
+
+	if len(collection.Options.Validator) > 0 || plan.ValidationAction.ValueString() != defaultValidationAction {
+		collection.Options.ValidationAction = plan.ValidationAction.ValueStringPointer()
+	}
+
+	dbCollection, err := r.client.CreateCollection(ctx, collection)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error creating MongoDB collection", err, collectionErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, dbCollection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Collection created")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		deferRead(ctx, req, resp)
+
+		return
+	}
+
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     plan.Name.ValueString(),
+		Database: plan.Database.ValueString(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.NotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		addClientError(ctx, &resp.Diagnostics, "Error reading MongoDB collection", err, collectionErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := &mongodb.ModifyCollectionOptions{
+		Name:     plan.Name.ValueString(),
+		Database: plan.Database.ValueString(),
+	}
+
+	changed := false
+
+	if !plan.Size.Equal(state.Size) && !plan.Size.IsNull() {
+		options.Size = plan.Size.ValueInt64Pointer()
+		changed = true
+	}
+
+	if !plan.Max.Equal(state.Max) {
+		// Zero removes the limit of documents
+		maxDocuments := plan.Max.ValueInt64()
+		options.Max = &maxDocuments
+		changed = true
+	}
+
+	if !plan.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) {
+		options.ExpireAfterSeconds = plan.ExpireAfterSeconds.ValueInt64Pointer()
+		options.DisableExpiration = plan.ExpireAfterSeconds.IsNull()
+		changed = true
+	}
+
+	if !plan.ChangeStreamPreAndPostImages.Equal(state.ChangeStreamPreAndPostImages) {
+		options.ChangeStreamPreAndPostImages = plan.ChangeStreamPreAndPostImages.ValueBoolPointer()
+		changed = true
+	}
+
+	if !plan.Validator.Equal(state.Validator) {
+		// An empty document removes the validator
+		validator := bson.D{}
+
+		if !plan.Validator.IsNull() {
+			err := parseExtendedJSON(plan.Validator.ValueString(), &validator)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to parse validator json", err.Error())
+
+				return
+			}
+		}
+
+		options.Validator = validator
+		changed = true
+	}
+
+	if !plan.ValidationLevel.Equal(state.ValidationLevel) {
+		options.ValidationLevel = plan.ValidationLevel.ValueStringPointer()
+		changed = true
+	}
+
+	if !plan.ValidationAction.Equal(state.ValidationAction) {
+		options.ValidationAction = plan.ValidationAction.ValueStringPointer()
+		changed = true
+	}
+
+	// Time series collections can't be converted in place, so both plan and state have the same form here
+	if !plan.TimeSeries.IsNull() && !plan.TimeSeries.IsUnknown() && !state.TimeSeries.IsNull() {
+		var planTimeSeries, stateTimeSeries TimeSeriesModel
+
+		resp.Diagnostics.Append(plan.TimeSeries.As(ctx, &planTimeSeries, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(state.TimeSeries.As(ctx, &stateTimeSeries, basetypes.ObjectAsOptions{})...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !planTimeSeries.Granularity.IsUnknown() && !planTimeSeries.Granularity.Equal(stateTimeSeries.Granularity) {
+			options.Granularity = planTimeSeries.Granularity.ValueStringPointer()
+			changed = true
+		}
+	}
+
+	var (
+		collection *mongodb.Collection
+		err        error
+	)
+
+	// collMod is skipped if none of the options it can change differ from the state
+	if changed {
+		collection, err = r.client.ModifyCollection(ctx, options)
+	} else {
+		collection, err = r.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+			Name:     options.Name,
+			Database: options.Database,
+		})
+	}
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error updating MongoDB collection", err, collectionErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Collection updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DropCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     plan.Name.ValueString(),
+		Database: plan.Database.ValueString(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error deleting MongoDB collection", err, collectionErrorAttributes)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Collection deleted")
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *CollectionResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	// Collection names can contain dots, database names can't
+	idParts := strings.SplitN(req.ID, ".", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: database.collection",
+		)
+
+		return
+	}
+
+	var plan CollectionResourceModel
+
+	collection, err := r.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     idParts[1],
+		Database: idParts[0],
+	})
+	if err != nil {
+		detail := fmt.Sprintf("Failed to read collection %s: %s", req.ID, err)
+		if errors.As(err, &mongodb.WrongTypeError{}) {
+			detail += "\n\nViews are imported with the mongodb_view resource."
+		}
+
+		resp.Diagnostics.AddError("Error importing collection", detail)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) checkClient(ctx context.Context, diags *diag.Diagnostics) bool {
+	return connectClient(ctx, r.client, diags)
+}
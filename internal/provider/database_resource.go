@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+const (
+	defaultInitialCollection = "_terraform"
+)
+
+var _ resource.Resource = &DatabaseResource{}
+var _ resource.ResourceWithConfigure = &DatabaseResource{}
+var _ resource.ResourceWithImportState = &DatabaseResource{}
+
+func NewDatabaseResource() resource.Resource {
+	return &DatabaseResource{}
+}
+
+type DatabaseResource struct {
+	client *mongodb.Client
+}
+
+type DatabaseResourceModel struct {
+	Name                     types.String `tfsdk:"name"`
+	InitialCollection        types.String `tfsdk:"initial_collection"`
+	PreventDestroyIfNotEmpty types.Bool   `tfsdk:"prevent_destroy_if_not_empty"`
+}
+
+func newDatabaseResourceModel() DatabaseResourceModel {
+	return DatabaseResourceModel{
+		InitialCollection:        types.StringValue(defaultInitialCollection),
+		PreventDestroyIfNotEmpty: types.BoolValue(false),
+	}
+}
+
+func (d *DatabaseResourceModel) updateState(database *mongodb.Database) {
+	d.Name = types.StringValue(database.Name)
+}
+
+func (r *DatabaseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+func (r *DatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "MongoDB Database resource",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the database",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"initial_collection": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("MongoDB creates databases implicitly with the first collection, "+
+					"so this collection is created together with the database. "+
+					"Changing it doesn't affect an existing database. %q is used by default", defaultInitialCollection),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultInitialCollection),
+			},
+			"prevent_destroy_if_not_empty": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to drop the database if it contains any documents",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *DatabaseResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	plan := newDatabaseResourceModel()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, err := r.client.CreateDatabase(ctx, &mongodb.CreateDatabaseOptions{
+		Name:              plan.Name.ValueString(),
+		InitialCollection: plan.InitialCollection.ValueString(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "failed to create database", err, nil)
+
+		return
+	}
+
+	plan.updateState(database)
+
+	tflog.Trace(ctx, "database created")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		deferRead(ctx, req, resp)
+
+		return
+	}
+
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	plan := newDatabaseResourceModel()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, err := r.client.GetDatabase(ctx, &mongodb.GetDatabaseOptions{
+		Name: plan.Name.ValueString(),
+	})
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			addClientError(ctx, &resp.Diagnostics, "failed to get database", err, nil)
+
+			return
+		}
+
+		tflog.Debug(ctx, "database not found, removing from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	plan.updateState(database)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only provider-side attributes can change without replacement
+	plan := newDatabaseResourceModel()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	plan := newDatabaseResourceModel()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PreventDestroyIfNotEmpty.ValueBool() {
+		database, err := r.client.GetDatabase(ctx, &mongodb.GetDatabaseOptions{
+			Name: plan.Name.ValueString(),
+		})
+
+		switch {
+		case errors.As(err, &mongodb.NotFoundError{}):
+			tflog.Debug(ctx, "database not found, removing from state")
+			resp.State.RemoveResource(ctx)
+
+			return
+		case err != nil:
+			addClientError(ctx, &resp.Diagnostics, "failed to get database", err, nil)
+
+			return
+		case !database.IsEmpty():
+			resp.Diagnostics.AddError(
+				"database is not empty",
+				fmt.Sprintf("Database %q contains %d documents and prevent_destroy_if_not_empty is set. "+
+					"Remove the data or disable the safeguard to drop it.", database.Name, database.Objects),
+			)
+
+			return
+		}
+	}
+
+	err := r.client.DropDatabase(ctx, &mongodb.DropDatabaseOptions{
+		Name: plan.Name.ValueString(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "failed to drop database", err, nil)
+
+		return
+	}
+
+	tflog.Trace(ctx, "database dropped")
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *DatabaseResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	plan := newDatabaseResourceModel()
+
+	database, err := r.client.GetDatabase(ctx, &mongodb.GetDatabaseOptions{
+		Name: req.ID,
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Failed to get database", err, nil)
+
+		return
+	}
+
+	plan.updateState(database)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DatabaseResource) checkClient(ctx context.Context, diags *diag.Diagnostics) bool {
+	return connectClient(ctx, r.client, diags)
+}
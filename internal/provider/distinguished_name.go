@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// dnSpecialChars can be escaped in attribute values, see RFC 2253 section 2.4
+	dnSpecialChars = ",=+<>#;\\\""
+	// dnEscapedChars must be escaped. Like OpenSSL, "=" and "#" (except the leading one) are accepted as is
+	dnEscapedChars = ",+<>;\\\""
+)
+
+// validateDistinguishedName checks that dn is a RFC 2253 string representation of a distinguished name,
+// which is how MongoDB expects X.509 certificate subjects, e.g. "CN=client,OU=eng,O=example.com".
+func validateDistinguishedName(dn string) error {
+	if dn == "" {
+		return errors.New("distinguished name is empty")
+	}
+
+	for _, rdn := range splitUnescaped(dn, ',') {
+		for _, attribute := range splitUnescaped(rdn, '+') {
+			err := validateAttributeTypeAndValue(attribute)
+			if err != nil {
+				return fmt.Errorf("invalid attribute %q: %w", attribute, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isDistinguishedName is used to tell X.509 usernames apart in import identifiers.
+func isDistinguishedName(value string) bool {
+	return strings.Contains(value, "=") && validateDistinguishedName(value) == nil
+}
+
+func validateAttributeTypeAndValue(attribute string) error {
+	attributeType, value, found := strings.Cut(attribute, "=")
+	if !found {
+		return errors.New("expected type=value")
+	}
+
+	if !isAttributeType(attributeType) {
+		return fmt.Errorf("%q is neither an attribute name nor an OID", attributeType)
+	}
+
+	switch {
+	case strings.HasPrefix(value, "#"):
+		return validateHexValue(value[1:])
+	case strings.HasPrefix(value, `"`):
+		return validateQuotedValue(value)
+	default:
+		return validateStringValue(value)
+	}
+}
+
+func isAttributeType(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	// Keyword, e.g. CN
+	if isAlpha(value[0]) {
+		for i := 1; i < len(value); i++ {
+			if !isAlpha(value[i]) && !isDigit(value[i]) && value[i] != '-' {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// OID, e.g. 2.5.4.3
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(value, "OID."), "oid."), ".") {
+		if part == "" {
+			return false
+		}
+
+		for i := 0; i < len(part); i++ {
+			if !isDigit(part[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func validateHexValue(value string) error {
+	if value == "" || len(value)%2 != 0 {
+		return errors.New("hex value must have an even number of digits")
+	}
+
+	for i := 0; i < len(value); i++ {
+		if !isHex(value[i]) {
+			return fmt.Errorf("invalid hex digit %q", value[i])
+		}
+	}
+
+	return nil
+}
+
+func validateQuotedValue(value string) error {
+	if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+		return errors.New("unterminated quoted value")
+	}
+
+	inner := value[1 : len(value)-1]
+
+	for i := 0; i < len(inner); i++ {
+		switch inner[i] {
+		case '\\':
+			i++
+			if i == len(inner) {
+				return errors.New("dangling escape")
+			}
+		case '"':
+			return errors.New("unescaped quote")
+		}
+	}
+
+	return nil
+}
+
+func validateStringValue(value string) error {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case c == '\\':
+			if i+1 == len(value) {
+				return errors.New("dangling escape")
+			}
+
+			next := value[i+1]
+
+			switch {
+			case strings.IndexByte(dnSpecialChars, next) >= 0 || next == ' ':
+				i++
+			case i+2 < len(value) && isHex(next) && isHex(value[i+2]):
+				i += 2
+			default:
+				return fmt.Errorf("invalid escape sequence at position %d", i)
+			}
+		case strings.IndexByte(dnEscapedChars, c) >= 0:
+			return fmt.Errorf("special character %q must be escaped", c)
+		}
+	}
+
+	if strings.HasPrefix(value, " ") || (strings.HasSuffix(value, " ") && !strings.HasSuffix(value, `\ `)) {
+		return errors.New("leading and trailing spaces must be escaped")
+	}
+
+	return nil
+}
+
+// splitUnescaped splits value on sep, skipping escaped and quoted separators.
+func splitUnescaped(value string, sep byte) []string {
+	var (
+		parts  []string
+		quoted bool
+		start  int
+	)
+
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
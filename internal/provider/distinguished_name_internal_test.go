@@ -0,0 +1,45 @@
+package provider
+
+import "testing"
+
+func TestValidateDistinguishedName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dn      string
+		wantErr bool
+	}{
+		{name: "simple", dn: "CN=client,OU=eng,O=example.com"},
+		{name: "escaped comma", dn: `CN=Doe\, John,O=example.com`},
+		{name: "hex escaped comma", dn: `CN=Doe\2C John,O=example.com`},
+		{name: "multi-valued RDN", dn: "CN=client+UID=42,O=example.com"},
+		{name: "escaped plus", dn: `CN=a\+b,O=example.com`},
+		{name: "quoted value with comma", dn: `CN="Doe, John",O=example.com`},
+		{name: "OID type", dn: "2.5.4.3=client,O=example.com"},
+		{name: "hex value", dn: "CN=#04024869,O=example.com"},
+		{name: "escaped trailing space", dn: `CN=client\ ,O=example.com`},
+		{name: "empty", dn: "", wantErr: true},
+		{name: "missing value separator", dn: "CN,O=example.com", wantErr: true},
+		{name: "empty RDN", dn: "CN=client,,O=example.com", wantErr: true},
+		{name: "empty attribute in multi-valued RDN", dn: "CN=client+,O=example.com", wantErr: true},
+		{name: "unescaped special character", dn: "CN=a<b,O=example.com", wantErr: true},
+		{name: "dangling escape", dn: `CN=client\`, wantErr: true},
+		{name: "invalid escape", dn: `CN=a\zb`, wantErr: true},
+		{name: "odd hex value", dn: "CN=#123", wantErr: true},
+		{name: "unterminated quote", dn: `CN="client`, wantErr: true},
+		{name: "invalid type", dn: "1CN=client", wantErr: true},
+		{name: "leading space", dn: "CN= client", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateDistinguishedName(tt.dn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDistinguishedName(%q) error = %v, wantErr %v", tt.dn, err, tt.wantErr)
+			}
+		})
+	}
+}
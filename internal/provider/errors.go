@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// errorHints explain server errors which are usually fixed in the configuration or in the database.
+var errorHints = map[int32]string{
+	mongodb.UnauthorizedCode: "The provider user lacks privileges for this operation. Managing users and roles " +
+		"requires userAdmin or userAdminAnyDatabase, managing collections and indexes requires dbAdmin " +
+		"on the database.",
+	mongodb.AuthenticationFailedCode: "Check username, password, auth_source and auth_mechanism " +
+		"of the provider configuration.",
+	mongodb.UserNotFoundCode: "The user was removed outside of Terraform. Refresh the state to create it again.",
+	mongodb.RoleNotFoundCode: "A referenced role doesn't exist. Built-in roles must be referenced in their " +
+		"database, custom roles must be created first, e.g. by referencing the mongodb_role resource.",
+	mongodb.NamespaceNotFoundCode: "The database or collection doesn't exist. Create it first or check its name.",
+	mongodb.NamespaceExistsCode: "A collection or view with this name already exists. " +
+		"Import it or choose another name.",
+	mongodb.DuplicateKeyCode: "Existing documents have duplicate values of the index keys. " +
+		"Remove the duplicates before making the index unique.",
+	mongodb.IndexOptionsConflictCode: "An index with the same keys, but a different name or options already exists. " +
+		"Import it or drop it first.",
+	mongodb.IndexKeySpecsConflictCode: "An index with the same name, but different keys already exists. " +
+		"Import it or choose another name.",
+	mongodb.CannotCreateIndexCode: "The server can't build an index with these keys and options, " +
+		"check the index type restrictions in the MongoDB documentation.",
+}
+
+// errorAttributes point server errors to the attribute which most likely caused them.
+type errorAttributes map[int32]path.Path
+
+// addClientError reports an operation which ran out of its timeout separately from server errors,
+// as it usually needs a longer timeout rather than a configuration change.
+// Known server errors get a hint and, if attributes has one for the error code, an attribute path.
+func addClientError(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	summary string,
+	err error,
+	attributes errorAttributes,
+) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		diags.AddError(
+			summary+": timeout exceeded",
+			"The operation didn't finish within its timeout, which can be changed in the timeouts block. "+
+				"The server may still complete it in the background.\n\n"+err.Error(),
+		)
+
+		return
+	}
+
+	var commandErr mongodb.FailedCommandError
+	if !errors.As(err, &commandErr) {
+		diags.AddError(summary, err.Error())
+
+		return
+	}
+
+	detail := err.Error()
+	if hint, ok := errorHints[commandErr.Code]; ok {
+		detail += "\n\n" + hint
+	}
+
+	if attribute, ok := attributes[commandErr.Code]; ok {
+		diags.AddAttributeError(attribute, summary, detail)
+
+		return
+	}
+
+	diags.AddError(summary, detail)
+}
@@ -2,37 +2,33 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
 )
@@ -42,8 +38,30 @@ var (
 	_ resource.ResourceWithConfigure      = &IndexResource{}
 	_ resource.ResourceWithImportState    = &IndexResource{}
 	_ resource.ResourceWithValidateConfig = &IndexResource{}
+	_ resource.ResourceWithModifyPlan     = &IndexResource{}
+	_ resource.ResourceWithUpgradeState   = &IndexResource{}
 )
 
+// indexErrorAttributes point index build errors to their usual cause.
+var indexErrorAttributes = errorAttributes{
+	mongodb.DuplicateKeyCode:          path.Root("unique"),
+	mongodb.IndexOptionsConflictCode:  path.Root("name"),
+	mongodb.IndexKeySpecsConflictCode: path.Root("keys"),
+	mongodb.CannotCreateIndexCode:     path.Root("keys"),
+}
+
+// indexServerRequirements are index options which older or compatible servers don't support.
+var indexServerRequirements = []serverRequirement{
+	{attribute: "hidden", minMajor: 4, minMinor: 4, unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB}},
+	{
+		attribute:   "wildcard_projection",
+		minMajor:    4,
+		minMinor:    2,
+		unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB},
+	},
+	{attribute: "collation", unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB}},
+}
+
 func NewIndexResource() resource.Resource {
 	return &IndexResource{}
 }
@@ -52,31 +70,44 @@ type IndexResource struct {
 	client *mongodb.Client
 }
 
-type CollationModel struct {
-	Locale          types.String `tfsdk:"locale"`
-	CaseLevel       types.Bool   `tfsdk:"case_level"`
-	CaseFirst       types.String `tfsdk:"case_first"`
-	Strength        types.Int64  `tfsdk:"strength"`
-	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
-	Alternate       types.String `tfsdk:"alternate"`
-	MaxVariable     types.String `tfsdk:"max_variable"`
-	Backwards       types.Bool   `tfsdk:"backwards"`
+type IndexKeyModel struct {
+	Field     types.String `tfsdk:"field"`
+	Direction types.String `tfsdk:"direction"`
 }
 
-func (c CollationModel) AttributeTypes() map[string]attr.Type {
+func (k IndexKeyModel) AttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"locale":           types.StringType,
-		"case_level":       types.BoolType,
-		"case_first":       types.StringType,
-		"strength":         types.Int64Type,
-		"numeric_ordering": types.BoolType,
-		"alternate":        types.StringType,
-		"max_variable":     types.StringType,
-		"backwards":        types.BoolType,
+		"field":     types.StringType,
+		"direction": types.StringType,
 	}
 }
 
 type IndexResourceModel struct {
+	Database                types.String  `tfsdk:"database"`
+	Collection              types.String  `tfsdk:"collection"`
+	Name                    types.String  `tfsdk:"name"`
+	Keys                    types.List    `tfsdk:"keys"`
+	Collation               types.Object  `tfsdk:"collation"`
+	WildcardProjection      types.Map     `tfsdk:"wildcard_projection"`
+	PartialFilterExpression types.String  `tfsdk:"partial_filter_expression"`
+	Unique                  types.Bool    `tfsdk:"unique"`
+	Sparse                  types.Bool    `tfsdk:"sparse"`
+	Hidden                  types.Bool    `tfsdk:"hidden"`
+	ExpireAfterSeconds      types.Int32   `tfsdk:"expire_after_seconds"`
+	SphereVersion           types.Int32   `tfsdk:"sphere_index_version"`
+	Bits                    types.Int32   `tfsdk:"bits"`
+	Min                     types.Float64 `tfsdk:"min"`
+	Max                     types.Float64 `tfsdk:"max"`
+	Weights                 types.Map     `tfsdk:"weights"`
+	DefaultLanguage         types.String  `tfsdk:"default_language"`
+	LanguageOverride        types.String  `tfsdk:"language_override"`
+	TextIndexVersion        types.Int32   `tfsdk:"text_index_version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// indexResourceModelV0 is the state of schema version 0, where keys were stored as an unordered map.
+type indexResourceModelV0 struct {
 	Database                types.String  `tfsdk:"database"`
 	Collection              types.String  `tfsdk:"collection"`
 	Name                    types.String  `tfsdk:"name"`
@@ -98,6 +129,23 @@ type IndexResourceModel struct {
 	TextIndexVersion        types.Int32   `tfsdk:"text_index_version"`
 }
 
+func (ind *IndexResourceModel) GetKeys(ctx context.Context, ptr *mongodb.IndexKeys) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	keyModels := make([]IndexKeyModel, 0, len(ind.Keys.Elements()))
+	diags.Append(ind.Keys.ElementsAs(ctx, &keyModels, false)...)
+
+	keys := make(mongodb.IndexKeys, 0, len(keyModels))
+
+	for _, key := range keyModels {
+		keys = append(keys, mongodb.NewIndexKey(key.Field.ValueString(), key.Direction.ValueString()))
+	}
+
+	*ptr = keys
+
+	return diags
+}
+
 func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.Index) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 
@@ -105,8 +153,17 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 	ind.Collection = types.StringValue(index.Collection)
 	ind.Name = types.StringValue(index.Name)
 
-	// Parse keys
-	keys, d := types.MapValueFrom(ctx, types.StringType, index.Keys.ToStringMap())
+	// Parse keys, keeping the order returned by MongoDB
+	keyModels := make([]IndexKeyModel, 0, len(index.Keys))
+
+	for _, key := range index.Keys {
+		keyModels = append(keyModels, IndexKeyModel{
+			Field:     types.StringValue(key.Field),
+			Direction: types.StringValue(key.DirectionString()),
+		})
+	}
+
+	keys, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: IndexKeyModel{}.AttributeTypes()}, keyModels)
 
 	diags.Append(d...)
 	if diags.HasError() {
@@ -116,26 +173,11 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 	ind.Keys = keys
 
 	// Parse collation
-	if index.Options.Collation != nil {
-		collation := CollationModel{
-			Locale:          types.StringValue(index.Options.Collation.Locale),
-			CaseLevel:       types.BoolValue(index.Options.Collation.CaseLevel),
-			CaseFirst:       types.StringValue(index.Options.Collation.CaseFirst),
-			Strength:        types.Int64Value(int64(index.Options.Collation.Strength)),
-			NumericOrdering: types.BoolValue(index.Options.Collation.NumericOrdering),
-			Alternate:       types.StringValue(index.Options.Collation.Alternate),
-			MaxVariable:     types.StringValue(index.Options.Collation.MaxVariable),
-			Backwards:       types.BoolValue(index.Options.Collation.Backwards),
-		}
-
-		ind.Collation, d = types.ObjectValueFrom(ctx, collation.AttributeTypes(), collation)
+	ind.Collation, d = newCollationObject(ctx, index.Options.Collation)
 
-		diags.Append(d...)
-		if diags.HasError() {
-			return diags
-		}
-	} else {
-		ind.Collation = types.ObjectNull(CollationModel{}.AttributeTypes())
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Parse wildcard projection
@@ -150,14 +192,18 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 
 	// Parse partial filter expression
 	if len(index.Options.PartialFilterExpression) > 0 {
-		partialFilterExpression, err := json.Marshal(index.Options.PartialFilterExpression)
+		partialFilterExpression, err := marshalExtendedJSON(index.Options.PartialFilterExpression)
 		if err != nil {
 			diags.AddError("Failed to parse partial filter expression", err.Error())
 
 			return diags
 		}
 
-		ind.PartialFilterExpression = types.StringValue(string(partialFilterExpression))
+		ind.PartialFilterExpression = semanticJSONValue(
+			ind.PartialFilterExpression,
+			partialFilterExpression,
+			index.Options.PartialFilterExpression,
+		)
 	}
 
 	// Parse weights
@@ -214,9 +260,10 @@ func (r *IndexResource) Metadata(_ context.Context, req resource.MetadataRequest
 	resp.TypeName = req.ProviderTypeName + "_index"
 }
 
-func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *IndexResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages MongoDB indexes",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"database": schema.StringAttribute{
 				Description: "Database name",
@@ -239,129 +286,74 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"collation": schema.SingleNestedAttribute{
-				Description: "Collation settings for string comparison",
-				Optional:    true,
-				Computed:    true,
-				Default:     objectdefault.StaticValue(types.ObjectNull(CollationModel{}.AttributeTypes())),
-				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
+			"collation": collationSchema(),
+			"keys": schema.ListNestedAttribute{
+				Description: "Ordered list of index key fields. The order matters for compound indexes",
+				Required:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
 				},
-				Attributes: map[string]schema.Attribute{
-					"locale": schema.StringAttribute{
-						Description: "The locale for string comparison",
-						Required:    true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"case_level": schema.BoolAttribute{
-						Description: "Whether to consider case in the 'Level=1' comparison",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"case_first": schema.StringAttribute{
-						Description: "Whether uppercase or lowercase should sort first",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("off"),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Validators: []validator.String{
-							stringvalidator.OneOf("upper", "lower", "off"),
-						},
-					},
-					"strength": schema.Int64Attribute{
-						Description: "Comparison level (1-5)",
-						Optional:    true,
-						Computed:    true,
-						Default:     int64default.StaticInt64(3),
-						PlanModifiers: []planmodifier.Int64{
-							int64planmodifier.RequiresReplace(),
-						},
-						Validators: []validator.Int64{
-							int64validator.Between(1, 5),
-						},
-					},
-					"numeric_ordering": schema.BoolAttribute{
-						Description: "Whether to compare numeric strings as numbers",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"alternate": schema.StringAttribute{
-						Description: "Whether spaces and punctuation are considered base characters",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("non-ignorable"),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Validators: []validator.String{
-							stringvalidator.OneOf("non-ignorable", "shifted"),
-						},
-					},
-					"max_variable": schema.StringAttribute{
-						Description: "Which characters are affected by 'alternate'",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("punct"),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Validators: []validator.String{
-							stringvalidator.OneOf("punct", "space"),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Description: "Indexed field name",
+							Required:    true,
 						},
-					},
-					"backwards": schema.BoolAttribute{
-						Description: "Whether to reverse secondary differences",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
+						"direction": schema.StringAttribute{
+							Description: "Index direction (1, -1) or index type (2d, 2dsphere, text, hashed)",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("1", "-1", "2d", "2dsphere", "text", "hashed"),
+							},
 						},
 					},
 				},
 			},
-			"keys": schema.MapAttribute{
-				Description: "Index key fields",
-				Required:    true,
-				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
-				},
-				Validators: []validator.Map{
-					mapvalidator.ValueStringsAre(stringvalidator.OneOf("1", "-1", "2d", "2dsphere", "text", "hashed")),
-				},
-			},
 			"unique": schema.BoolAttribute{
-				Description: "Whether the index enforces unique values",
-				Optional:    true,
+				Description: "Whether the index enforces unique values. " +
+					"An existing index is converted to unique in place, the reverse change recreates the index",
+				Optional: true,
 				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+					boolplanmodifier.RequiresReplaceIf(
+						func(
+							_ context.Context,
+							req planmodifier.BoolRequest,
+							resp *boolplanmodifier.RequiresReplaceIfFuncResponse,
+						) {
+							// Only conversion to a unique index is supported by collMod
+							resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+						},
+						"Removing the unique constraint requires the index to be recreated",
+						"Removing the unique constraint requires the index to be recreated",
+					),
 				},
 			},
 			"partial_filter_expression": schema.StringAttribute{
-				Description: "JSON encoded filter expression that limits indexed documents.",
+				Description: "JSON (or Extended JSON) encoded filter expression that limits indexed documents.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"expire_after_seconds": schema.Int32Attribute{
-				Description: "TTL in seconds for TTL indexes",
+				Description: "TTL in seconds for TTL indexes. Changed in place, removing it recreates the index",
 				Optional:    true,
 				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.RequiresReplace(),
+					int32planmodifier.RequiresReplaceIf(
+						func(
+							_ context.Context,
+							req planmodifier.Int32Request,
+							resp *int32planmodifier.RequiresReplaceIfFuncResponse,
+						) {
+							// collMod can change the TTL value, but can't turn a TTL index into a regular one
+							resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+						},
+						"Removing the TTL requires the index to be recreated",
+						"Removing the TTL requires the index to be recreated",
+					),
 				},
 				Validators: []validator.Int32{
 					int32validator.AtLeast(0),
@@ -398,11 +390,8 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"hidden": schema.BoolAttribute{
-				Description: "Whether the index should be hidden from the query planner",
+				Description: "Whether the index should be hidden from the query planner. Changed in place",
 				Optional:    true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
-				},
 			},
 			"bits": schema.Int32Attribute{
 				Description: "Number of bits for geospatial index precision",
@@ -470,6 +459,9 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -489,19 +481,41 @@ func (r *IndexResource) ValidateConfig(
 		return
 	}
 
-	var keysMap map[string]string
+	var keys []IndexKeyModel
 
-	resp.Diagnostics.Append(config.Keys.ElementsAs(ctx, &keysMap, false)...)
+	resp.Diagnostics.Append(config.Keys.ElementsAs(ctx, &keys, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !config.ExpireAfterSeconds.IsNull() {
-		isWildcard := false
-		if _, exists := keysMap["$**"]; exists {
+	isWildcard := false
+	fields := make(map[string]struct{}, len(keys))
+
+	for i, key := range keys {
+		if key.Field.IsNull() || key.Field.IsUnknown() {
+			continue
+		}
+
+		field := key.Field.ValueString()
+
+		if _, exists := fields[field]; exists {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keys").AtListIndex(i).AtName("field"),
+				"Duplicate Index Key",
+				fmt.Sprintf("Field %q is used more than once in index keys", field),
+			)
+
+			return
+		}
+
+		fields[field] = struct{}{}
+
+		if field == "$**" || strings.HasSuffix(field, ".$**") {
 			isWildcard = true
 		}
+	}
 
+	if !config.ExpireAfterSeconds.IsNull() {
 		if isWildcard {
 			resp.Diagnostics.AddError(
 				"Invalid TTL Index Configuration",
@@ -512,15 +526,19 @@ func (r *IndexResource) ValidateConfig(
 	}
 
 	// Validate partial filter expression operators
-	if config.PartialFilterExpression.IsNull() {
+	if config.PartialFilterExpression.IsNull() || config.PartialFilterExpression.IsUnknown() {
 		return
 	}
 
 	var filterExpr map[string]interface{}
 
-	err := json.Unmarshal([]byte(config.PartialFilterExpression.ValueString()), &filterExpr)
+	err := parseExtendedJSON(config.PartialFilterExpression.ValueString(), &filterExpr)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to parse partial filter expression json", err.Error())
+		resp.Diagnostics.AddAttributeError(
+			path.Root("partial_filter_expression"),
+			"Failed to parse partial filter expression json",
+			err.Error(),
+		)
 
 		return
 	}
@@ -544,8 +562,16 @@ func (r *IndexResource) Configure(_ context.Context, req resource.ConfigureReque
 	r.client = p.client
 }
 
+func (r *IndexResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkServerRequirements(ctx, r.client, req.Plan, req.Config, indexServerRequirements, &resp.Diagnostics)
+}
+
 func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -577,35 +603,20 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if !plan.Collation.IsNull() && !plan.Collation.IsUnknown() {
-		collation := &CollationModel{}
-		resp.Diagnostics.Append(plan.Collation.As(ctx, collation, basetypes.ObjectAsOptions{})...)
+		resp.Diagnostics.Append(getCollation(ctx, plan.Collation, &index.Options.Collation)...)
 
 		if resp.Diagnostics.HasError() {
 			return
 		}
-
-		index.Options.Collation = &options.Collation{
-			Locale:          collation.Locale.ValueString(),
-			CaseLevel:       collation.CaseLevel.ValueBool(),
-			CaseFirst:       collation.CaseFirst.ValueString(),
-			Strength:        int(collation.Strength.ValueInt64()),
-			NumericOrdering: collation.NumericOrdering.ValueBool(),
-			Alternate:       collation.Alternate.ValueString(),
-			MaxVariable:     collation.MaxVariable.ValueString(),
-			Backwards:       collation.Backwards.ValueBool(),
-		}
 	}
 
 	// Parse keys
 	if !plan.Keys.IsNull() && !plan.Keys.IsUnknown() {
-		indexKeys := map[string]string{}
-		resp.Diagnostics.Append(plan.Keys.ElementsAs(ctx, &indexKeys, false)...)
+		resp.Diagnostics.Append(plan.GetKeys(ctx, &index.Keys)...)
 
 		if resp.Diagnostics.HasError() {
 			return
 		}
-
-		index.Keys = mongodb.ConvertMap(indexKeys, true)
 	}
 
 	// Parse WildcardProjection
@@ -622,7 +633,7 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Parse PartialFilterExpression
 	if !plan.PartialFilterExpression.IsNull() && !plan.PartialFilterExpression.IsUnknown() {
-		err := json.Unmarshal([]byte(plan.PartialFilterExpression.ValueString()), &index.Options.PartialFilterExpression)
+		err := parseExtendedJSON(plan.PartialFilterExpression.ValueString(), &index.Options.PartialFilterExpression)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to parse partial filter expression json", err.Error())
 
@@ -642,12 +653,18 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 		index.Options.Weights = weights
 	}
 
+	createTimeout, d := plan.Timeouts.Create(ctx, defaultIndexBuildTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	dbIndex, err := r.client.CreateIndex(ctx, index)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating MongoDB index",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "Error creating MongoDB index", err, indexErrorAttributes)
 
 		return
 	}
@@ -661,7 +678,13 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if r.client == nil {
+		deferRead(ctx, req, resp)
+
+		return
+	}
+
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -672,6 +695,15 @@ func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, d := plan.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	index, err := r.client.GetIndex(ctx, &mongodb.GetIndexOptions{
 		Name:       plan.Name.ValueString(),
 		Database:   plan.Database.ValueString(),
@@ -684,10 +716,7 @@ func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error reading MongoDB index",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "Error reading MongoDB index", err, indexErrorAttributes)
 
 		return
 	}
@@ -702,20 +731,80 @@ func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// MongoDB indexes are immutable, so just setting the plan as the new state
-	var plan IndexResourceModel
-	diags := req.Plan.Get(ctx, &plan)
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state IndexResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
-	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// All other attributes require replacement, so only these options can be changed here
+	options := &mongodb.ModifyIndexOptions{
+		Name:       plan.Name.ValueString(),
+		Database:   plan.Database.ValueString(),
+		Collection: plan.Collection.ValueString(),
+	}
+
+	changed := false
+
+	if plan.Hidden.ValueBool() != state.Hidden.ValueBool() {
+		options.Hidden = plan.Hidden.ValueBoolPointer()
+		if options.Hidden == nil {
+			options.Hidden = new(bool)
+		}
+
+		changed = true
+	}
+
+	if !plan.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) && !plan.ExpireAfterSeconds.IsNull() {
+		options.ExpireAfterSeconds = plan.ExpireAfterSeconds.ValueInt32Pointer()
+		changed = true
+	}
+
+	if plan.Unique.ValueBool() && !state.Unique.ValueBool() {
+		options.Unique = plan.Unique.ValueBoolPointer()
+		changed = true
+	}
+
+	if !changed {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+		return
+	}
+
+	updateTimeout, d := plan.Timeouts.Update(ctx, defaultIndexBuildTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	index, err := r.client.ModifyIndex(ctx, options)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error updating MongoDB index", err, indexErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, index)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Index updated")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *IndexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -726,16 +815,22 @@ func (r *IndexResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, d := plan.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteIndex(ctx, &mongodb.GetIndexOptions{
 		Name:       plan.Name.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Collection: plan.Collection.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error deleting MongoDB index",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "Error deleting MongoDB index", err, indexErrorAttributes)
 	}
 
 	tflog.Trace(ctx, "Index deleted")
@@ -761,7 +856,7 @@ func (r *IndexResource) ImportState(
 	collection := idParts[1]
 	indexName := strings.Join(idParts[2:], ".")
 
-	var plan IndexResourceModel
+	plan := IndexResourceModel{Timeouts: nullTimeouts()}
 
 	index, err := r.client.GetIndex(ctx, &mongodb.GetIndexOptions{
 		Name:       indexName,
@@ -785,15 +880,87 @@ func (r *IndexResource) ImportState(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func (r *IndexResource) checkClient(diag diag.Diagnostics) bool {
-	if r.client == nil {
-		diag.AddError(
-			"MongoDB client is not configured",
-			"Expected configured MongoDB client. Please report this issue to the provider developers.",
-		)
+func (r *IndexResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// Schema version 0 differs only in keys, which were a map of field to direction
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+	priorSchema.Attributes = maps.Clone(schemaResp.Schema.Attributes)
+	// The timeouts block was added in version 1
+	priorSchema.Blocks = nil
+	priorSchema.Attributes["keys"] = schema.MapAttribute{
+		Required:    true,
+		ElementType: types.StringType,
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeIndexStateV0,
+		},
+	}
+}
+
+func upgradeIndexStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior indexResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keysMap := map[string]string{}
 
-		return false
+	resp.Diagnostics.Append(prior.Keys.ElementsAs(ctx, &keysMap, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The original order is lost in the map, the next read will restore the order used by MongoDB
+	keyModels := make([]IndexKeyModel, 0, len(keysMap))
+
+	for _, field := range slices.Sorted(maps.Keys(keysMap)) {
+		keyModels = append(keyModels, IndexKeyModel{
+			Field:     types.StringValue(field),
+			Direction: types.StringValue(keysMap[field]),
+		})
+	}
+
+	keys, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: IndexKeyModel{}.AttributeTypes()}, keyModels)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return true
+	state := IndexResourceModel{
+		Database:                prior.Database,
+		Collection:              prior.Collection,
+		Name:                    prior.Name,
+		Keys:                    keys,
+		Collation:               prior.Collation,
+		WildcardProjection:      prior.WildcardProjection,
+		PartialFilterExpression: prior.PartialFilterExpression,
+		Unique:                  prior.Unique,
+		Sparse:                  prior.Sparse,
+		Hidden:                  prior.Hidden,
+		ExpireAfterSeconds:      prior.ExpireAfterSeconds,
+		SphereVersion:           prior.SphereVersion,
+		Bits:                    prior.Bits,
+		Min:                     prior.Min,
+		Max:                     prior.Max,
+		Weights:                 prior.Weights,
+		DefaultLanguage:         prior.DefaultLanguage,
+		LanguageOverride:        prior.LanguageOverride,
+		TextIndexVersion:        prior.TextIndexVersion,
+		Timeouts:                nullTimeouts(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *IndexResource) checkClient(ctx context.Context, diags *diag.Diagnostics) bool {
+	return connectClient(ctx, r.client, diags)
 }
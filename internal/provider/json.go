@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// parseExtendedJSON parses a JSON encoded document attribute.
+// Relaxed Extended JSON is accepted, so values like {"$date": "..."} keep their BSON types.
+func parseExtendedJSON(value string, ptr any) error {
+	return bson.UnmarshalExtJSON([]byte(value), false, ptr)
+}
+
+// marshalExtendedJSON encodes a document from MongoDB as relaxed Extended JSON.
+func marshalExtendedJSON(doc any) (string, error) {
+	out, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// marshalExtendedJSONArray encodes an array of documents, e.g. an aggregation pipeline, as relaxed Extended JSON.
+// Top level arrays are not supported by bson.MarshalExtJSON, so documents are encoded one by one.
+func marshalExtendedJSONArray(docs bson.A) (string, error) {
+	out := make([]string, 0, len(docs))
+
+	for _, doc := range docs {
+		encoded, err := marshalExtendedJSON(doc)
+		if err != nil {
+			return "", err
+		}
+
+		out = append(out, encoded)
+	}
+
+	return "[" + strings.Join(out, ",") + "]", nil
+}
+
+// extendedJSONEqual reports whether value encodes the same document or array as doc read from MongoDB.
+// Both sides are compared as canonical Extended JSON, so key order and BSON types matter and formatting doesn't,
+// e.g. {"$numberLong": "5"} equals an int64 read back, but {"$sort": {"b": 1, "a": 1}} differs from {"a": 1, "b": 1}.
+func extendedJSONEqual(value string, doc any) bool {
+	var parsed any
+
+	switch doc.(type) {
+	case bson.A:
+		var array bson.A
+
+		if parseExtendedJSON(value, &array) != nil {
+			return false
+		}
+
+		parsed = array
+	default:
+		var document bson.D
+
+		if parseExtendedJSON(value, &document) != nil {
+			return false
+		}
+
+		parsed = document
+	}
+
+	// Top level arrays can't be marshalled, so both sides are wrapped in a document
+	a, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: parsed}}, true, false)
+	if err != nil {
+		return false
+	}
+
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: doc}}, true, false)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(a, b)
+}
+
+// semanticJSONValue keeps the current value if it encodes doc, which is read from MongoDB,
+// so formatting and canonical Extended JSON are not reported as drift. Otherwise value, doc as relaxed JSON, is used.
+func semanticJSONValue(current types.String, value string, doc any) types.String {
+	if !current.IsNull() && !current.IsUnknown() && extendedJSONEqual(current.ValueString(), doc) {
+		return current
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestExtendedJSONEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		doc   any
+		want  bool
+	}{
+		{
+			name:  "same document",
+			value: `{"a": 1, "b": "x"}`,
+			doc:   bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: "x"}},
+			want:  true,
+		},
+		{
+			name:  "formatting",
+			value: "{\n  \"a\":1 }",
+			doc:   bson.D{{Key: "a", Value: int32(1)}},
+			want:  true,
+		},
+		{
+			name:  "key order",
+			value: `{"b": 1, "a": 1}`,
+			doc:   bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(1)}},
+			want:  false,
+		},
+		{
+			name:  "canonical int64",
+			value: `{"n": {"$numberLong": "5"}}`,
+			doc:   bson.D{{Key: "n", Value: int64(5)}},
+			want:  true,
+		},
+		{
+			name:  "int32 against int64",
+			value: `{"n": 5}`,
+			doc:   bson.D{{Key: "n", Value: int64(5)}},
+			want:  false,
+		},
+		{
+			name:  "different value",
+			value: `{"a": 1}`,
+			doc:   bson.D{{Key: "a", Value: int32(2)}},
+			want:  false,
+		},
+		{
+			name:  "pipeline",
+			value: `[{"$match": {"status": "A"}}, {"$sort": {"b": 1, "a": -1}}]`,
+			doc: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "status", Value: "A"}}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "b", Value: int32(1)}, {Key: "a", Value: int32(-1)}}}},
+			},
+			want: true,
+		},
+		{
+			name:  "pipeline with reordered sort keys",
+			value: `[{"$sort": {"b": 1, "a": 1}}]`,
+			doc: bson.A{
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(1)}}}},
+			},
+			want: false,
+		},
+		{
+			name:  "pipeline with reordered stages",
+			value: `[{"$limit": 1}, {"$skip": 1}]`,
+			doc: bson.A{
+				bson.D{{Key: "$skip", Value: int32(1)}},
+				bson.D{{Key: "$limit", Value: int32(1)}},
+			},
+			want: false,
+		},
+		{
+			name:  "invalid json",
+			value: `{"a":`,
+			doc:   bson.D{},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := extendedJSONEqual(tt.value, tt.doc); got != tt.want {
+				t.Errorf("extendedJSONEqual(%s) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
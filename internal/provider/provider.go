@@ -2,14 +2,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
 )
@@ -20,6 +29,10 @@ var (
 
 const (
 	defaultDatabase = "admin"
+
+	x509AuthMechanism = "MONGODB-X509"
+
+	defaultMaxRetries = 3
 )
 
 type MongodbProvider struct {
@@ -39,6 +52,68 @@ type MongodbProviderModel struct {
 	Certificate        types.String `tfsdk:"certificate"`
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 	DirectConnection   types.Bool   `tfsdk:"direct_connection"`
+
+	ClientCertificate   types.String `tfsdk:"client_certificate"`
+	ClientKey           types.String `tfsdk:"client_key"`
+	ClientKeyPassphrase types.String `tfsdk:"client_key_passphrase"`
+
+	PasswordFile          types.String `tfsdk:"password_file"`
+	CertificateFile       types.String `tfsdk:"certificate_file"`
+	ClientCertificateFile types.String `tfsdk:"client_certificate_file"`
+	ClientKeyFile         types.String `tfsdk:"client_key_file"`
+
+	SSHTunnel types.Object `tfsdk:"ssh_tunnel"`
+
+	ServerSelectionTimeout types.String `tfsdk:"server_selection_timeout"`
+	ConnectTimeout         types.String `tfsdk:"connect_timeout"`
+	SocketTimeout          types.String `tfsdk:"socket_timeout"`
+	MaxPoolSize            types.Int64  `tfsdk:"max_pool_size"`
+	AppName                types.String `tfsdk:"app_name"`
+	Compressors            types.List   `tfsdk:"compressors"`
+	MaxRetries             types.Int64  `tfsdk:"max_retries"`
+
+	ProxyHost     types.String `tfsdk:"proxy_host"`
+	ProxyPort     types.Int64  `tfsdk:"proxy_port"`
+	ProxyUsername types.String `tfsdk:"proxy_username"`
+	ProxyPassword types.String `tfsdk:"proxy_password"`
+}
+
+type SSHTunnelModel struct {
+	Host                  types.String `tfsdk:"host"`
+	Port                  types.Int64  `tfsdk:"port"`
+	User                  types.String `tfsdk:"user"`
+	PrivateKey            types.String `tfsdk:"private_key"`
+	PrivateKeyPassphrase  types.String `tfsdk:"private_key_passphrase"`
+	UseAgent              types.Bool   `tfsdk:"use_agent"`
+	KnownHosts            types.String `tfsdk:"known_hosts"`
+	InsecureIgnoreHostKey types.Bool   `tfsdk:"insecure_ignore_host_key"`
+}
+
+func (s *SSHTunnelModel) options() *mongodb.SSHTunnelOptions {
+	return &mongodb.SSHTunnelOptions{
+		Host:                  s.Host.ValueString(),
+		Port:                  int(s.Port.ValueInt64()),
+		User:                  s.User.ValueString(),
+		PrivateKey:            s.PrivateKey.ValueString(),
+		PrivateKeyPassphrase:  s.PrivateKeyPassphrase.ValueString(),
+		UseAgent:              s.UseAgent.ValueBool(),
+		KnownHosts:            s.KnownHosts.ValueString(),
+		InsecureIgnoreHostKey: s.InsecureIgnoreHostKey.ValueBool(),
+	}
+}
+
+// proxyOptions returns nil if no proxy attribute is set, so the connection string can still configure a proxy.
+func (m *MongodbProviderModel) proxyOptions() *mongodb.ProxyOptions {
+	if m.ProxyHost.IsNull() && m.ProxyPort.IsNull() && m.ProxyUsername.IsNull() && m.ProxyPassword.IsNull() {
+		return nil
+	}
+
+	return &mongodb.ProxyOptions{
+		Host:     m.ProxyHost.ValueString(),
+		Port:     int(m.ProxyPort.ValueInt64()),
+		Username: m.ProxyUsername.ValueString(),
+		Password: m.ProxyPassword.ValueString(),
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -57,42 +132,50 @@ func (p *MongodbProvider) Metadata(_ context.Context, _ provider.MetadataRequest
 func (p *MongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "MongoDB resources management.<br>\n" +
-			"**You must provide either `connection_string` or `hosts` to connect to.**",
+			"**You must provide either `connection_string` or `hosts` to connect to.**<br>\n" +
+			"Unset attributes fall back to `*_file` attributes, then to `MONGODB_*` environment variables, " +
+			"then to `MONGODB_*_FILE` environment variables pointing to files. " +
+			"Sources ignored because a higher-precedence one is set are reported as warnings, " +
+			"settings read from files or environment variables are logged at the INFO level.",
 
 		Attributes: map[string]schema.Attribute{
 			"connection_string": schema.StringAttribute{
-				MarkdownDescription: "MongoDB connection string",
+				MarkdownDescription: "MongoDB connection string. Can be set with `MONGODB_URI` environment variable",
 				Optional:            true,
 			},
 			"hosts": schema.ListAttribute{
-				MarkdownDescription: "MongoDB hosts",
-				ElementType:         types.StringType,
-				Optional:            true,
+				MarkdownDescription: "MongoDB hosts. " +
+					"Can be set with `MONGODB_HOSTS` environment variable as a comma separated list",
+				ElementType: types.StringType,
+				Optional:    true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Username",
+				MarkdownDescription: "Username. Can be set with `MONGODB_USERNAME` environment variable",
 				Optional:            true,
 				Sensitive:           true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password",
+				MarkdownDescription: "Password. Can be set with `MONGODB_PASSWORD` environment variable",
 				Optional:            true,
 				Sensitive:           true,
 			},
 			"auth_source": schema.StringAttribute{
-				MarkdownDescription: "AuthSource database",
+				MarkdownDescription: "AuthSource database. Can be set with `MONGODB_AUTH_SOURCE` environment variable",
 				Optional:            true,
 			},
 			"auth_mechanism": schema.StringAttribute{
-				MarkdownDescription: "Authentication mechanism (e.g., MONGODB-AWS, SCRAM-SHA-256)",
-				Optional:            true,
+				MarkdownDescription: "Authentication mechanism (e.g., MONGODB-AWS, SCRAM-SHA-256). " +
+					"`MONGODB-X509` authenticates with `client_certificate` without a password, " +
+					"`auth_source` defaults to `$external` for it. " +
+					"Can be set with `MONGODB_AUTH_MECHANISM` environment variable",
+				Optional: true,
 			},
 			"replica_set": schema.StringAttribute{
-				MarkdownDescription: "Replica set name",
+				MarkdownDescription: "Replica set name. Can be set with `MONGODB_REPLICA_SET` environment variable",
 				Optional:            true,
 			},
 			"tls": schema.BoolAttribute{
-				MarkdownDescription: "Enable TLS",
+				MarkdownDescription: "Enable TLS. Can be set with `MONGODB_TLS` environment variable",
 				Optional:            true,
 			},
 			"certificate": schema.StringAttribute{
@@ -104,8 +187,178 @@ func (p *MongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:            true,
 			},
 			"direct_connection": schema.BoolAttribute{
-				MarkdownDescription: "Direct connection to MongoDB",
+				MarkdownDescription: "Direct connection to MongoDB. " +
+					"Can be set with `MONGODB_DIRECT_CONNECTION` environment variable",
+				Optional: true,
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "Client certificate PEM string for mutual TLS. " +
+					"It can include the private key. Enables TLS",
+				Optional: true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "Client private key PEM string, if it's not a part of `client_certificate`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"ssh_tunnel": schema.SingleNestedAttribute{
+				MarkdownDescription: "Connect to every MongoDB server through an SSH jump host",
 				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						MarkdownDescription: "Jump host address",
+						Required:            true,
+					},
+					"port": schema.Int64Attribute{
+						MarkdownDescription: "Jump host SSH port, 22 by default",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+					"user": schema.StringAttribute{
+						MarkdownDescription: "SSH user",
+						Required:            true,
+					},
+					"private_key": schema.StringAttribute{
+						MarkdownDescription: "SSH private key PEM string",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"private_key_passphrase": schema.StringAttribute{
+						MarkdownDescription: "Passphrase of the encrypted SSH private key",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("private_key")),
+						},
+					},
+					"use_agent": schema.BoolAttribute{
+						MarkdownDescription: "Authenticate with keys from the SSH agent listening on `SSH_AUTH_SOCK`",
+						Optional:            true,
+					},
+					"known_hosts": schema.StringAttribute{
+						MarkdownDescription: "Known hosts file content used to verify the jump host key. " +
+							"`~/.ssh/known_hosts` is used by default",
+						Optional: true,
+					},
+					"insecure_ignore_host_key": schema.BoolAttribute{
+						MarkdownDescription: "Don't verify the jump host key",
+						Optional:            true,
+						Validators: []validator.Bool{
+							boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("known_hosts")),
+						},
+					},
+				},
+			},
+			"server_selection_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for a suitable server, e.g. `30s`. " +
+					"Driver default is 30 seconds",
+				Optional:   true,
+				Validators: []validator.String{durationValidator{}},
+			},
+			"connect_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for a new connection, e.g. `10s`. Driver default is 30 seconds",
+				Optional:            true,
+				Validators:          []validator.String{durationValidator{}},
+			},
+			"socket_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for each operation, e.g. `1m`. " +
+					"It's applied as the driver operation timeout, which replaces `socketTimeoutMS`. " +
+					"The driver ignores it when the operation already has a deadline, " +
+					"so it doesn't apply to resources with `timeouts`, e.g. indexes, users and roles, " +
+					"their `timeouts` apply instead. No limit by default",
+				Optional:   true,
+				Validators: []validator.String{durationValidator{}},
+			},
+			"max_pool_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of connections to each server. Driver default is 100",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "Application name reported to the server, " +
+					"e.g. in `currentOp` and logs. Defaults to `terraform-provider-mongodb/<version>`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+			"compressors": schema.ListAttribute{
+				MarkdownDescription: "Compressors to negotiate with the server in order of preference: " +
+					"`snappy`, `zlib` and `zstd`",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf("snappy", "zlib", "zstd")),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("How many times to retry reads, upserts and index builds "+
+					"which failed with transient errors, e.g. during replica set elections. "+
+					"Delays between attempts grow exponentially. Defaults to %d, `0` disables retries",
+					defaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 20),
+				},
+			},
+			"proxy_host": schema.StringAttribute{
+				MarkdownDescription: "SOCKS5 proxy host to connect to every MongoDB server through. " +
+					"Proxy attributes can also be set with `proxyHost`, `proxyPort`, `proxyUsername` and " +
+					"`proxyPassword` connection string options",
+				Optional: true,
+			},
+			"proxy_port": schema.Int64Attribute{
+				MarkdownDescription: "SOCKS5 proxy port, 1080 by default",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"proxy_username": schema.StringAttribute{
+				MarkdownDescription: "SOCKS5 proxy username",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("proxy_password")),
+				},
+			},
+			"proxy_password": schema.StringAttribute{
+				MarkdownDescription: "SOCKS5 proxy password",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("proxy_username")),
+				},
+			},
+			"password_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with the password. " +
+					"Can be set with `MONGODB_PASSWORD_FILE` environment variable",
+				Optional: true,
+			},
+			"certificate_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the certificate PEM file. " +
+					"Can be set with `MONGODB_CA_FILE` environment variable",
+				Optional: true,
+			},
+			"client_certificate_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the client certificate PEM file. " +
+					"Can be set with `MONGODB_CLIENT_CERTIFICATE_FILE` environment variable",
+				Optional: true,
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the client private key PEM file. " +
+					"Can be set with `MONGODB_CLIENT_KEY_FILE` environment variable",
+				Optional: true,
+			},
+			"client_key_passphrase": schema.StringAttribute{
+				MarkdownDescription: "Passphrase of the encrypted client private key. " +
+					"Can be set with `MONGODB_CLIENT_KEY_PASSPHRASE` environment variable",
+				Optional:  true,
+				Sensitive: true,
 			},
 		},
 	}
@@ -124,8 +377,67 @@ func (p *MongodbProvider) Configure(
 		return
 	}
 
+	// Values like hosts can depend on resources created in the same run
+	if !req.Config.Raw.IsFullyKnown() {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+
+			return
+		}
+
+		tflog.Warn(ctx, "provider configuration is unknown, MongoDB client is not created")
+
+		resp.ResourceData = p
+		resp.DataSourceData = p
+
+		return
+	}
+
+	resp.Diagnostics.Append(data.resolveSettings(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ClientCertificate.IsNull() && (!data.ClientKey.IsNull() || !data.ClientKeyPassphrase.IsNull()) {
+		resp.Diagnostics.AddError(
+			"Missing client certificate",
+			"client_key and client_key_passphrase require client_certificate",
+		)
+
+		return
+	}
+
+	x509Auth := strings.EqualFold(data.AuthMechanism.ValueString(), x509AuthMechanism)
+
+	if x509Auth && data.Password.ValueString() != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Password is not supported",
+			x509AuthMechanism+" authenticates with the client certificate and can't be used with a password",
+		)
+
+		return
+	}
+
 	if data.AuthSource.IsNull() {
-		data.AuthSource = types.StringValue(defaultDatabase)
+		if x509Auth {
+			data.AuthSource = types.StringValue(externalDatabase)
+		} else {
+			data.AuthSource = types.StringValue(defaultDatabase)
+		}
+	}
+
+	var sshTunnel *mongodb.SSHTunnelOptions
+
+	if !data.SSHTunnel.IsNull() {
+		var tunnel SSHTunnelModel
+
+		resp.Diagnostics.Append(data.SSHTunnel.As(ctx, &tunnel, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		sshTunnel = tunnel.options()
 	}
 
 	var err error
@@ -134,11 +446,34 @@ func (p *MongodbProvider) Configure(
 	diag := data.Hosts.ElementsAs(ctx, &hosts, false)
 	resp.Diagnostics.Append(diag...)
 
+	var compressors []string
+
+	if !data.Compressors.IsNull() {
+		resp.Diagnostics.Append(data.Compressors.ElementsAs(ctx, &compressors, false)...)
+	}
+
+	serverSelectionTimeout, diag := parseDurationSetting("server_selection_timeout", data.ServerSelectionTimeout)
+	resp.Diagnostics.Append(diag...)
+
+	connectTimeout, diag := parseDurationSetting("connect_timeout", data.ConnectTimeout)
+	resp.Diagnostics.Append(diag...)
+
+	socketTimeout, diag := parseDurationSetting("socket_timeout", data.SocketTimeout)
+	resp.Diagnostics.Append(diag...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	p.client, err = mongodb.New(ctx, &mongodb.ClientOptions{
+	if data.MaxRetries.IsNull() {
+		data.MaxRetries = types.Int64Value(defaultMaxRetries)
+	}
+
+	if data.AppName.IsNull() {
+		data.AppName = types.StringValue("terraform-provider-mongodb/" + p.Version)
+	}
+
+	p.client, err = mongodb.New(&mongodb.ClientOptions{
 		ConnectionString:   data.ConnectionString.ValueString(),
 		Hosts:              hosts,
 		Username:           data.Username.ValueString(),
@@ -150,28 +485,54 @@ func (p *MongodbProvider) Configure(
 		Certificate:        data.Certificate.ValueString(),
 		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
 		DirectConnection:   data.DirectConnection.ValueBool(),
+
+		ClientCertificate:   data.ClientCertificate.ValueString(),
+		ClientKey:           data.ClientKey.ValueString(),
+		ClientKeyPassphrase: data.ClientKeyPassphrase.ValueString(),
+
+		ServerSelectionTimeout: serverSelectionTimeout,
+		ConnectTimeout:         connectTimeout,
+		SocketTimeout:          socketTimeout,
+		MaxPoolSize:            uint64(data.MaxPoolSize.ValueInt64()),
+		AppName:                data.AppName.ValueString(),
+		Compressors:            compressors,
+		MaxRetries:             int(data.MaxRetries.ValueInt64()),
+
+		SSHTunnel: sshTunnel,
+		Proxy:     data.proxyOptions(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to connect to MongoDB",
+			"Failed to create MongoDB client",
 			err.Error(),
 		)
 	}
 
 	resp.ResourceData = p
+	resp.DataSourceData = p
 }
 
 func (p *MongodbProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewServerInfoDataSource,
+		NewUserDataSource,
+		NewUsersDataSource,
+		NewRoleDataSource,
+		NewRolesDataSource,
+	}
 }
 
 func (p *MongodbProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	// connection_string or hosts are checked in Configure, as they can be set with environment variables
 	return []provider.ConfigValidator{
-		// Users must provide either connection_string or hosts list
-		providervalidator.AtLeastOneOf(
-			path.MatchRoot("connection_string"),
-			path.MatchRoot("hosts"),
+		providervalidator.Conflicting(path.MatchRoot("password"), path.MatchRoot("password_file")),
+		providervalidator.Conflicting(path.MatchRoot("certificate"), path.MatchRoot("certificate_file")),
+		providervalidator.Conflicting(
+			path.MatchRoot("client_certificate"),
+			path.MatchRoot("client_certificate_file"),
 		),
+		providervalidator.Conflicting(path.MatchRoot("client_key"), path.MatchRoot("client_key_file")),
+		providervalidator.Conflicting(path.MatchRoot("ssh_tunnel"), path.MatchRoot("proxy_host")),
 	}
 }
 
@@ -180,5 +541,8 @@ func (p *MongodbProvider) Resources(_ context.Context) []func() resource.Resourc
 		NewUserResource,
 		NewRoleResource,
 		NewIndexResource,
+		NewDatabaseResource,
+		NewCollectionResource,
+		NewViewResource,
 	}
 }
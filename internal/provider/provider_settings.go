@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	envPrefix = "MONGODB_"
+)
+
+// stringSetting is a provider string attribute with its fallbacks. Sources in order of precedence:
+// the attribute itself, the *_file attribute, the environment variable and the *_FILE environment variable.
+type stringSetting struct {
+	name     string
+	value    *types.String
+	fileName string
+	file     *types.String
+	env      string
+	fileEnv  string
+}
+
+// settingSource is where a setting value comes from. Values of file sources are read when the source is used.
+type settingSource struct {
+	description string
+	value       string
+	file        string
+	// attribute is set for sources in the provider configuration
+	attribute string
+}
+
+// sources lists the sources which are set, in order of precedence.
+func (s stringSetting) sources() []settingSource {
+	var sources []settingSource
+
+	if !s.value.IsNull() {
+		sources = append(sources, settingSource{
+			description: "attribute " + s.name,
+			value:       s.value.ValueString(),
+			attribute:   s.name,
+		})
+	}
+
+	if s.file != nil && !s.file.IsNull() {
+		sources = append(sources, settingSource{
+			description: fmt.Sprintf("file %q from attribute %s", s.file.ValueString(), s.fileName),
+			file:        s.file.ValueString(),
+			attribute:   s.fileName,
+		})
+	}
+
+	if s.env != "" && os.Getenv(s.env) != "" {
+		sources = append(sources, settingSource{
+			description: "environment variable " + s.env,
+			value:       os.Getenv(s.env),
+		})
+	}
+
+	if s.fileEnv != "" && os.Getenv(s.fileEnv) != "" {
+		sources = append(sources, settingSource{
+			description: fmt.Sprintf("file %q from environment variable %s", os.Getenv(s.fileEnv), s.fileEnv),
+			file:        os.Getenv(s.fileEnv),
+		})
+	}
+
+	return sources
+}
+
+// resolve replaces a null value with the first available fallback.
+// It returns the source of a value which doesn't come from the attribute itself, so it can be logged.
+func (s stringSetting) resolve() (string, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	sources := s.sources()
+	if len(sources) == 0 {
+		return "", diags
+	}
+
+	source := sources[0]
+
+	for _, shadowed := range sources[1:] {
+		addShadowedSettingWarning(&diags, s.name, source.description, shadowed.description)
+	}
+
+	if source.attribute == s.name {
+		return "", diags
+	}
+
+	value := source.value
+
+	if source.file != "" {
+		var err error
+
+		value, err = readSettingFile(source.file)
+		if err != nil {
+			summary := fmt.Sprintf("Failed to read %s", s.name)
+			detail := fmt.Sprintf("Failed to read %s: %s", source.description, err)
+
+			if source.attribute != "" {
+				diags.AddAttributeError(path.Root(source.attribute), summary, detail)
+			} else {
+				diags.AddError(summary, detail)
+			}
+
+			return "", diags
+		}
+	}
+
+	*s.value = types.StringValue(value)
+
+	return source.description, diags
+}
+
+// addShadowedSettingWarning reports a source which is ignored, as it may be set by mistake.
+func addShadowedSettingWarning(diags *diag.Diagnostics, name, used, ignored string) {
+	diags.AddWarning(
+		fmt.Sprintf("Provider setting %s is set more than once", name),
+		fmt.Sprintf("Using %s, %s is ignored.", used, ignored),
+	)
+}
+
+// readSettingFile reads a secret or a PEM file. The trailing newline is dropped,
+// so files written by editors and secret managers can be used as is.
+func readSettingFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// resolveBoolSetting replaces a null value with the environment variable.
+// It returns the source of the value, if it comes from the environment variable.
+func resolveBoolSetting(name string, value *types.Bool, env string) (string, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return "", diags
+	}
+
+	if !value.IsNull() {
+		addShadowedSettingWarning(&diags, name, "attribute "+name, "environment variable "+env)
+
+		return "", diags
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Invalid %s", name),
+			fmt.Sprintf("Environment variable %s must be a boolean, got %q", env, raw),
+		)
+
+		return "", diags
+	}
+
+	*value = types.BoolValue(parsed)
+
+	return "environment variable " + env, diags
+}
+
+// resolveSettings fills unset attributes from files and environment variables.
+// Sources of resolved values are only logged, as environment variables are the usual setup in CI.
+// Ignored sources are reported as warnings, since they are likely set by mistake.
+func (m *MongodbProviderModel) resolveSettings(ctx context.Context) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	settings := []stringSetting{
+		{name: "connection_string", value: &m.ConnectionString, env: envPrefix + "URI"},
+		{name: "username", value: &m.Username, env: envPrefix + "USERNAME"},
+		{
+			name:     "password",
+			value:    &m.Password,
+			fileName: "password_file",
+			file:     &m.PasswordFile,
+			env:      envPrefix + "PASSWORD",
+			fileEnv:  envPrefix + "PASSWORD_FILE",
+		},
+		{name: "auth_source", value: &m.AuthSource, env: envPrefix + "AUTH_SOURCE"},
+		{name: "auth_mechanism", value: &m.AuthMechanism, env: envPrefix + "AUTH_MECHANISM"},
+		{name: "replica_set", value: &m.ReplicaSet, env: envPrefix + "REPLICA_SET"},
+		{
+			name:     "certificate",
+			value:    &m.Certificate,
+			fileName: "certificate_file",
+			file:     &m.CertificateFile,
+			fileEnv:  envPrefix + "CA_FILE",
+		},
+		{
+			name:     "client_certificate",
+			value:    &m.ClientCertificate,
+			fileName: "client_certificate_file",
+			file:     &m.ClientCertificateFile,
+			fileEnv:  envPrefix + "CLIENT_CERTIFICATE_FILE",
+		},
+		{
+			name:     "client_key",
+			value:    &m.ClientKey,
+			fileName: "client_key_file",
+			file:     &m.ClientKeyFile,
+			fileEnv:  envPrefix + "CLIENT_KEY_FILE",
+		},
+		{
+			name:    "client_key_passphrase",
+			value:   &m.ClientKeyPassphrase,
+			env:     envPrefix + "CLIENT_KEY_PASSPHRASE",
+			fileEnv: envPrefix + "CLIENT_KEY_PASSPHRASE_FILE",
+		},
+	}
+
+	addResolved := func(name, source string, d diag.Diagnostics) {
+		diags.Append(d...)
+
+		if source == "" {
+			return
+		}
+
+		tflog.Info(ctx, "provider setting resolved", map[string]interface{}{
+			"setting": name,
+			"source":  source,
+		})
+	}
+
+	for _, setting := range settings {
+		source, d := setting.resolve()
+		addResolved(setting.name, source, d)
+	}
+
+	source, d := resolveBoolSetting("tls", &m.TLS, envPrefix+"TLS")
+	addResolved("tls", source, d)
+
+	source, d = resolveBoolSetting("direct_connection", &m.DirectConnection, envPrefix+"DIRECT_CONNECTION")
+	addResolved("direct_connection", source, d)
+
+	// Hosts are comma separated
+	if hosts := os.Getenv(envPrefix + "HOSTS"); hosts != "" {
+		if m.Hosts.IsNull() {
+			values := strings.Split(hosts, ",")
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+
+			m.Hosts, d = types.ListValueFrom(ctx, types.StringType, values)
+			addResolved("hosts", "environment variable "+envPrefix+"HOSTS", d)
+		} else {
+			addShadowedSettingWarning(&diags, "hosts", "attribute hosts", "environment variable "+envPrefix+"HOSTS")
+		}
+	}
+
+	if m.ConnectionString.IsNull() && m.Hosts.IsNull() {
+		diags.AddError(
+			"Missing MongoDB address",
+			fmt.Sprintf("Either connection_string or hosts must be set, in the provider configuration "+
+				"or with %sURI or %sHOSTS environment variables", envPrefix, envPrefix),
+		)
+	}
+
+	return diags
+}
+
+// parseDurationSetting returns zero for a null value, so the driver default is kept.
+func parseDurationSetting(name string, value types.String) (time.Duration, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	if value.IsNull() {
+		return 0, diags
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid duration",
+			fmt.Sprintf("Failed to parse %s: %s", name, err),
+		)
+	}
+
+	return duration, diags
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator accepts positive Go durations, e.g. "30s" or "1m30s".
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, e.g. 30s"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(
+	_ context.Context,
+	req validator.StringRequest,
+	resp *validator.StringResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && duration > 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid duration",
+		fmt.Sprintf("%q is not a positive duration, use a number with a unit suffix, e.g. 30s or 1m30s",
+			req.ConfigValue.ValueString()),
+	)
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ datasource.DataSource              = &RoleDataSource{}
+	_ datasource.DataSourceWithConfigure = &RoleDataSource{}
+)
+
+func NewRoleDataSource() datasource.DataSource {
+	return &RoleDataSource{}
+}
+
+type RoleDataSource struct {
+	client *mongodb.Client
+}
+
+type RoleDataSourceModel struct {
+	Name                types.String `tfsdk:"name"`
+	Database            types.String `tfsdk:"database"`
+	IsBuiltin           types.Bool   `tfsdk:"is_builtin"`
+	Roles               types.Set    `tfsdk:"roles"`
+	Privileges          types.Set    `tfsdk:"privileges"`
+	InheritedRoles      types.Set    `tfsdk:"inherited_roles"`
+	InheritedPrivileges types.Set    `tfsdk:"inherited_privileges"`
+
+	AuthenticationRestrictions types.Set `tfsdk:"authentication_restrictions"`
+}
+
+func (r *RoleDataSourceModel) updateState(ctx context.Context, role *mongodb.Role) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	r.Name = types.StringValue(role.Name)
+	r.Database = types.StringValue(role.Database)
+	r.IsBuiltin = types.BoolValue(role.IsBuiltin)
+
+	roles, d := role.Roles.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	inheritedRoles, d := role.InheritedRoles.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	privileges, d := role.Privileges.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	inheritedPrivileges, d := role.InheritedPrivileges.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	r.Roles = *roles
+	r.InheritedRoles = *inheritedRoles
+	r.Privileges = *privileges
+	r.InheritedPrivileges = *inheritedPrivileges
+
+	r.AuthenticationRestrictions, d = newAuthenticationRestrictionsSet(
+		ctx,
+		types.SetNull(types.ObjectType{AttrTypes: AuthenticationRestrictionModel{}.AttributeTypes()}),
+		role.AuthenticationRestrictions,
+	)
+	diags.Append(d...)
+
+	return diags
+}
+
+// roleDataSourceAttributes are shared by mongodb_role and the roles of mongodb_roles.
+func roleDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Role name",
+			Computed:            true,
+		},
+		"database": schema.StringAttribute{
+			MarkdownDescription: "Database the role is defined in",
+			Computed:            true,
+		},
+		"is_builtin": schema.BoolAttribute{
+			MarkdownDescription: "Whether the role is a built-in role",
+			Computed:            true,
+		},
+		"roles":      shortRolesDataSourceSchema("Roles the role inherits directly"),
+		"privileges": privilegesDataSourceSchema("Privileges granted by the role itself"),
+		"inherited_roles": shortRolesDataSourceSchema("All roles the role inherits, " +
+			"including the ones inherited by its roles"),
+		"inherited_privileges": privilegesDataSourceSchema("All privileges the role grants, " +
+			"including the ones of inherited roles. This is what the role effectively grants to a user"),
+		"authentication_restrictions": authenticationRestrictionsDataSourceSchema(),
+	}
+}
+
+// privilegesDataSourceSchema is the read-only version of privileges in mongodb_role.
+func privilegesDataSourceSchema(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"resource": schema.SingleNestedAttribute{
+					MarkdownDescription: "Resources the actions apply to",
+					Computed:            true,
+					Attributes: map[string]schema.Attribute{
+						"db": schema.StringAttribute{
+							MarkdownDescription: "Database name, \"\" for all databases",
+							Computed:            true,
+						},
+						"collection": schema.StringAttribute{
+							MarkdownDescription: "Collection name, \"\" for all collections",
+							Computed:            true,
+						},
+						"cluster": schema.BoolAttribute{
+							MarkdownDescription: "Whether the actions are cluster-wide",
+							Computed:            true,
+						},
+						"any_resource": schema.BoolAttribute{
+							MarkdownDescription: "Whether the actions apply to every resource in the system",
+							Computed:            true,
+						},
+					},
+				},
+				"actions": schema.SetAttribute{
+					MarkdownDescription: "Actions permitted on the resource",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (d *RoleDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (d *RoleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := roleDataSourceAttributes()
+
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "Role name, custom or built-in",
+		Required:            true,
+	}
+	attributes["database"] = schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Database the role is defined in. "+
+			"%q is used by default", defaultDatabase),
+		Optional: true,
+		Computed: true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing MongoDB role, including built-in roles, " +
+			"with the roles and privileges it inherits",
+		Attributes: attributes,
+	}
+}
+
+func (d *RoleDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkDataSourceClient(ctx, d.client, req, resp) {
+		return
+	}
+
+	var config RoleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := config.Database.ValueString()
+	if database == "" {
+		database = defaultDatabase
+	}
+
+	role, err := d.client.GetRole(ctx, &mongodb.GetRoleOptions{
+		Name:     config.Name.ValueString(),
+		Database: database,
+	})
+	if errors.As(err, &mongodb.NotFoundError{}) {
+		resp.Diagnostics.AddError(
+			"Role not found",
+			fmt.Sprintf("Role %q doesn't exist in database %q", config.Name.ValueString(), database),
+		)
+
+		return
+	}
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error reading role", err, nil)
+
+		return
+	}
+
+	resp.Diagnostics.Append(config.updateState(ctx, role)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -24,7 +27,21 @@ import (
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithConfigure = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithModifyPlan = &RoleResource{}
 var _ resource.ResourceWithConfigValidators = &RoleResource{}
+var _ resource.ResourceWithUpgradeState = &RoleResource{}
+
+var roleErrorAttributes = errorAttributes{
+	mongodb.RoleNotFoundCode: path.Root("roles"),
+}
+
+// roleServerRequirements are role options which compatible servers don't support.
+var roleServerRequirements = []serverRequirement{
+	{
+		attribute:   "authentication_restrictions",
+		unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB, mongodb.FlavorCosmosDB},
+	},
+}
 
 func NewRoleResource() resource.Resource {
 	return &RoleResource{}
@@ -39,12 +56,20 @@ type RoleResourceModel struct {
 	Database   types.String `tfsdk:"database"`
 	Roles      types.Set    `tfsdk:"roles"`
 	Privileges types.Set    `tfsdk:"privileges"`
+
+	AuthenticationRestrictions types.Set `tfsdk:"authentication_restrictions"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func newRoleResourceModel() RoleResourceModel {
 	return RoleResourceModel{
 		Roles:      types.SetNull(types.ObjectType{AttrTypes: mongodb.ShortRoleAttributeTypes}),
 		Privileges: types.SetNull(types.ObjectType{AttrTypes: mongodb.PrivilegeAttributeTypes}),
+		AuthenticationRestrictions: types.SetNull(types.ObjectType{
+			AttrTypes: AuthenticationRestrictionModel{}.AttributeTypes(),
+		}),
+		Timeouts: nullTimeouts(),
 	}
 }
 
@@ -64,6 +89,13 @@ func (r *RoleResourceModel) updateState(ctx context.Context, role *mongodb.Role)
 	diags.Append(d...)
 	r.Privileges = *privileges
 
+	r.AuthenticationRestrictions, d = newAuthenticationRestrictionsSet(
+		ctx,
+		r.AuthenticationRestrictions,
+		role.AuthenticationRestrictions,
+	)
+	diags.Append(d...)
+
 	return diags
 }
 
@@ -71,9 +103,10 @@ func (r *RoleResource) Metadata(_ context.Context, req resource.MetadataRequest,
 	resp.TypeName = req.ProviderTypeName + "_role"
 }
 
-func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *RoleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "MongoDB Role resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -118,14 +151,41 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"resource": schema.ObjectAttribute{
+						"resource": schema.SingleNestedAttribute{
 							MarkdownDescription: "A document that specifies the resources " +
-								"upon which the privilege actions apply",
-							AttributeTypes: map[string]attr.Type{
-								"db":         types.StringType,
-								"collection": types.StringType,
-							},
+								"upon which the privilege actions apply. Exactly one of `db` and `collection`, " +
+								"`cluster` or `any_resource` must be set",
 							Required: true,
+							Attributes: map[string]schema.Attribute{
+								"db": schema.StringAttribute{
+									MarkdownDescription: "Database name, \"\" for all databases",
+									Optional:            true,
+									Computed:            true,
+									Default:             stringdefault.StaticString(""),
+								},
+								"collection": schema.StringAttribute{
+									MarkdownDescription: "Collection name, \"\" for all collections",
+									Optional:            true,
+									Computed:            true,
+									Default:             stringdefault.StaticString(""),
+								},
+								"cluster": schema.BoolAttribute{
+									MarkdownDescription: "Grant cluster-wide actions, " +
+										"like `serverStatus` or `replSetGetStatus`",
+									Optional: true,
+									Computed: true,
+									Default:  booldefault.StaticBool(false),
+								},
+								"any_resource": schema.BoolAttribute{
+									MarkdownDescription: "Grant actions on every resource in the system",
+									Optional:            true,
+									Computed:            true,
+									Default:             booldefault.StaticBool(false),
+								},
+							},
+							Validators: []validator.Object{
+								privilegeResourceValidator{},
+							},
 						},
 						"actions": schema.SetAttribute{
 							MarkdownDescription: "An array of actions permitted on the resource",
@@ -135,6 +195,10 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"authentication_restrictions": authenticationRestrictionsSchema(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
@@ -158,8 +222,16 @@ func (r *RoleResource) Configure(_ context.Context, req resource.ConfigureReques
 	r.client = p.client
 }
 
+func (r *RoleResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkServerRequirements(ctx, r.client, req.Plan, req.Config, roleServerRequirements, &resp.Diagnostics)
+}
+
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -190,17 +262,33 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		}
 	}
 
+	// Parse authentication restrictions
+	var restrictions mongodb.AuthenticationRestrictions
+
+	resp.Diagnostics.Append(getAuthenticationRestrictions(ctx, plan.AuthenticationRestrictions, &restrictions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, d := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	role, err := r.client.UpsertRole(ctx, &mongodb.Role{
 		Name:       plan.Name.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Privileges: privileges,
 		Roles:      roles,
+
+		AuthenticationRestrictions: restrictions,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"failed to upsert role",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "failed to upsert role", err, roleErrorAttributes)
 
 		return
 	}
@@ -215,7 +303,13 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if r.client == nil {
+		deferRead(ctx, req, resp)
+
+		return
+	}
+
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -226,16 +320,22 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, d := plan.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	role, err := r.client.GetRole(ctx, &mongodb.GetRoleOptions{
 		Name:     plan.Name.ValueString(),
 		Database: plan.Database.ValueString(),
 	})
 	if err != nil {
 		if !errors.As(err, &mongodb.NotFoundError{}) {
-			resp.Diagnostics.AddError(
-				"failed to get role",
-				err.Error(),
-			)
+			addClientError(ctx, &resp.Diagnostics, "failed to get role", err, roleErrorAttributes)
 
 			return
 		}
@@ -255,7 +355,7 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -282,17 +382,38 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Parse authentication restrictions
+	var restrictions mongodb.AuthenticationRestrictions
+
+	resp.Diagnostics.Append(getAuthenticationRestrictions(ctx, plan.AuthenticationRestrictions, &restrictions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, d := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	role, err := r.client.UpsertRole(ctx, &mongodb.Role{
 		Name:       plan.Name.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Privileges: privileges,
 		Roles:      roles,
+
+		AuthenticationRestrictions: restrictions,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"failed to upsert role",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "failed to upsert role", err, roleErrorAttributes)
+
+		// Record changes which were already applied, so the next plan shows only the rest
+		if errors.As(err, &mongodb.RolesChangeError{}) {
+			r.recordPartialUpdate(ctx, &plan, resp)
+		}
 
 		return
 	}
@@ -306,8 +427,37 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+func (r *RoleResource) recordPartialUpdate(
+	ctx context.Context,
+	plan *RoleResourceModel,
+	resp *resource.UpdateResponse,
+) {
+	// The update may have run out of its timeout, the changes are still worth recording
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultReadTimeout)
+	defer cancel()
+
+	role, err := r.client.GetRole(ctx, &mongodb.GetRoleOptions{
+		Name:     plan.Name.ValueString(),
+		Database: plan.Database.ValueString(),
+	})
+	if err != nil {
+		tflog.Warn(ctx, "failed to read role after partial update", map[string]any{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	diags := plan.updateState(ctx, role)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -318,15 +468,21 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, d := plan.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteRole(ctx, &mongodb.DeleteRoleOptions{
 		Name:     plan.Name.ValueString(),
 		Database: plan.Database.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"failed to delete role",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "failed to delete role", err, roleErrorAttributes)
 	}
 
 	tflog.Trace(ctx, "role deleted")
@@ -338,7 +494,7 @@ func (r *RoleResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -369,10 +525,7 @@ func (r *RoleResource) ImportState(
 		Database: database,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to get role",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "Failed to get role", err, roleErrorAttributes)
 
 		return
 	}
@@ -394,15 +547,148 @@ func (r *RoleResource) ConfigValidators(_ context.Context) []resource.ConfigVali
 	}
 }
 
-func (r *RoleResource) checkClient(diag diag.Diagnostics) bool {
-	if r.client == nil {
-		diag.AddError(
-			"MongoDB client is not configured",
-			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+var _ validator.Object = privilegeResourceValidator{}
+
+// privilegeResourceValidator ensures exactly one resource form is used.
+type privilegeResourceValidator struct{}
+
+func (v privilegeResourceValidator) Description(_ context.Context) string {
+	return "exactly one of db and collection, cluster or any_resource must be set"
+}
+
+func (v privilegeResourceValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v privilegeResourceValidator) ValidateObject(
+	ctx context.Context,
+	req validator.ObjectRequest,
+	resp *validator.ObjectResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	for _, value := range attributes {
+		if value.IsUnknown() {
+			return
+		}
+	}
+
+	db := !attributes["db"].IsNull()
+	collection := !attributes["collection"].IsNull()
+
+	if db != collection {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid privilege resource",
+			"db and collection must be set together",
 		)
 
-		return false
+		return
+	}
+
+	forms := 0
+
+	if db {
+		forms++
+	}
+
+	for _, name := range []string{"cluster", "any_resource"} {
+		if value, ok := attributes[name].(types.Bool); ok && value.ValueBool() {
+			forms++
+		}
+	}
+
+	if forms != 1 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid privilege resource",
+			"Exactly one of db and collection, cluster = true or any_resource = true must be set",
+		)
 	}
+}
+
+// privilegeModelV0 reads privileges of schema version 0, where the resource only had db and collection.
+// States written after cluster and any_resource were added are read the same way.
+type privilegeModelV0 struct {
+	Resource struct {
+		DB          types.String `tfsdk:"db"`
+		Collection  types.String `tfsdk:"collection"`
+		Cluster     types.Bool   `tfsdk:"cluster"`
+		AnyResource types.Bool   `tfsdk:"any_resource"`
+	} `tfsdk:"resource"`
+	Actions []string `tfsdk:"actions"`
+}
+
+func (r *RoleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// Missing attributes are read as null, so the current schema reads both shapes of version 0
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+	priorSchema.Attributes = maps.Clone(schemaResp.Schema.Attributes)
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeRoleStateV0,
+		},
+	}
+}
+
+func upgradeRoleStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	state := newRoleResourceModel()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Privileges.IsNull() {
+		state.Privileges = types.SetNull(types.ObjectType{AttrTypes: mongodb.PrivilegeAttributeTypes})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+		return
+	}
+
+	var prior []privilegeModelV0
+
+	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Null values become the defaults: "" for db and collection, false for cluster and any_resource
+	privileges := make(mongodb.Privileges, 0, len(prior))
+
+	for _, privilege := range prior {
+		privileges = append(privileges, mongodb.Privilege{
+			Resource: mongodb.Resource{
+				DB:          privilege.Resource.DB.ValueString(),
+				Collection:  privilege.Resource.Collection.ValueString(),
+				Cluster:     privilege.Resource.Cluster.ValueBool(),
+				AnyResource: privilege.Resource.AnyResource.ValueBool(),
+			},
+			Actions: privilege.Actions,
+		})
+	}
+
+	set, d := privileges.ToTerraformSet(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Privileges = *set
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-	return true
+func (r *RoleResource) checkClient(ctx context.Context, diags *diag.Diagnostics) bool {
+	return connectClient(ctx, r.client, diags)
 }
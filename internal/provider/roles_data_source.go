@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ datasource.DataSource              = &RolesDataSource{}
+	_ datasource.DataSourceWithConfigure = &RolesDataSource{}
+)
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+type RolesDataSource struct {
+	client *mongodb.Client
+}
+
+type RolesDataSourceModel struct {
+	Database         types.String          `tfsdk:"database"`
+	ShowBuiltinRoles types.Bool            `tfsdk:"show_builtin_roles"`
+	Roles            []RoleDataSourceModel `tfsdk:"roles"`
+}
+
+func (d *RolesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists MongoDB roles of a database with rolesInfo, " +
+			"with the roles and privileges they inherit",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database to list roles of",
+				Required:            true,
+			},
+			"show_builtin_roles": schema.BoolAttribute{
+				MarkdownDescription: "List built-in roles along with custom ones. Defaults to `false`",
+				Optional:            true,
+			},
+			"roles": schema.ListNestedAttribute{
+				MarkdownDescription: "Roles of the database, ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: roleDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *RolesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkDataSourceClient(ctx, d.client, req, resp) {
+		return
+	}
+
+	var config RolesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := d.client.ListRoles(ctx, &mongodb.ListRolesOptions{
+		Database:         config.Database.ValueString(),
+		ShowBuiltinRoles: config.ShowBuiltinRoles.ValueBool(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error listing roles", err, nil)
+
+		return
+	}
+
+	slices.SortFunc(roles, func(a, b mongodb.Role) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	config.Roles = make([]RoleDataSourceModel, len(roles))
+
+	for i := range roles {
+		resp.Diagnostics.Append(config.Roles[i].updateState(ctx, &roles[i])...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ datasource.DataSource              = &ServerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &ServerInfoDataSource{}
+)
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &ServerInfoDataSource{}
+}
+
+type ServerInfoDataSource struct {
+	client *mongodb.Client
+}
+
+type ServerInfoDataSourceModel struct {
+	Flavor                       types.String `tfsdk:"flavor"`
+	Version                      types.String `tfsdk:"version"`
+	GitVersion                   types.String `tfsdk:"git_version"`
+	Modules                      types.List   `tfsdk:"modules"`
+	Topology                     types.String `tfsdk:"topology"`
+	IsWritablePrimary            types.Bool   `tfsdk:"is_writable_primary"`
+	SetName                      types.String `tfsdk:"set_name"`
+	Hosts                        types.List   `tfsdk:"hosts"`
+	MaxWireVersion               types.Int32  `tfsdk:"max_wire_version"`
+	LogicalSessionTimeoutMinutes types.Int32  `tfsdk:"logical_session_timeout_minutes"`
+	FeatureCompatibilityVersion  types.String `tfsdk:"feature_compatibility_version"`
+}
+
+func (m *ServerInfoDataSourceModel) updateState(ctx context.Context, server *mongodb.ServerDetails) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	m.Flavor = types.StringValue(string(server.Flavor))
+	m.Version = types.StringValue(server.Version)
+	m.GitVersion = types.StringValue(server.GitVersion)
+	m.Topology = types.StringValue(string(server.Topology))
+	m.IsWritablePrimary = types.BoolValue(server.IsWritablePrimary)
+	m.MaxWireVersion = types.Int32Value(server.MaxWireVersion)
+	m.LogicalSessionTimeoutMinutes = types.Int32PointerValue(server.LogicalSessionTimeoutMinutes)
+
+	// Empty values mean the server doesn't report them
+	m.SetName = types.StringNull()
+	if server.ReplicaSet != "" {
+		m.SetName = types.StringValue(server.ReplicaSet)
+	}
+
+	m.FeatureCompatibilityVersion = types.StringNull()
+	if server.FeatureCompatibilityVersion != "" {
+		m.FeatureCompatibilityVersion = types.StringValue(server.FeatureCompatibilityVersion)
+	}
+
+	modules := server.Modules
+	if modules == nil {
+		modules = []string{}
+	}
+
+	var d diag.Diagnostics
+
+	m.Modules, d = types.ListValueFrom(ctx, types.StringType, modules)
+	diags.Append(d...)
+
+	hosts := server.Hosts
+	if hosts == nil {
+		hosts = []string{}
+	}
+
+	m.Hosts, d = types.ListValueFrom(ctx, types.StringType, hosts)
+	diags.Append(d...)
+
+	return diags
+}
+
+func (d *ServerInfoDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+func (d *ServerInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the version, topology and capabilities of the MongoDB server " +
+			"from buildInfo, hello and getParameter commands",
+		Attributes: map[string]schema.Attribute{
+			"flavor": schema.StringAttribute{
+				Description: "Server implementation: mongodb, documentdb, cosmosdb or ferretdb. " +
+					"Compatible servers report the MongoDB version they implement. " +
+					"The flavor is detected from buildInfo and hello replies, " +
+					"DocumentDB and CosmosDB host names are used as a fallback",
+				Computed: true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Server version",
+				Computed:    true,
+			},
+			"git_version": schema.StringAttribute{
+				Description: "Commit the server was built from",
+				Computed:    true,
+			},
+			"modules": schema.ListAttribute{
+				Description: "Server modules, e.g. enterprise",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"topology": schema.StringAttribute{
+				Description: "Deployment topology: standalone, replica_set or sharded",
+				Computed:    true,
+			},
+			"is_writable_primary": schema.BoolAttribute{
+				Description: "Whether the connected server accepts writes",
+				Computed:    true,
+			},
+			"set_name": schema.StringAttribute{
+				Description: "Replica set name, null if the server isn't a replica set member",
+				Computed:    true,
+			},
+			"hosts": schema.ListAttribute{
+				Description: "Replica set members, empty if the server isn't a replica set member",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"max_wire_version": schema.Int32Attribute{
+				Description: "Latest wire protocol version supported by the server",
+				Computed:    true,
+			},
+			"logical_session_timeout_minutes": schema.Int32Attribute{
+				Description: "Session timeout in minutes, null if the server doesn't support sessions",
+				Computed:    true,
+			},
+			"feature_compatibility_version": schema.StringAttribute{
+				Description: "Feature compatibility version, " +
+					"null if the server doesn't report it or the user isn't allowed to read it",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ServerInfoDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *ServerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkDataSourceClient(ctx, d.client, req, resp) {
+		return
+	}
+
+	server, err := d.client.GetServerDetails(ctx)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error reading server info", err, nil)
+
+		return
+	}
+
+	var state ServerInfoDataSourceModel
+
+	resp.Diagnostics.Append(state.updateState(ctx, server)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Defaults of the timeouts block. Index builds scan the whole collection, so they get more time.
+const (
+	defaultCreateTimeout     = 20 * time.Minute
+	defaultReadTimeout       = 5 * time.Minute
+	defaultUpdateTimeout     = 20 * time.Minute
+	defaultDeleteTimeout     = 20 * time.Minute
+	defaultIndexBuildTimeout = time.Hour
+)
+
+// nullTimeouts is used for states which don't come from a configuration, e.g. imported ones.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
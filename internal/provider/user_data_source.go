@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ datasource.DataSource              = &UserDataSource{}
+	_ datasource.DataSourceWithConfigure = &UserDataSource{}
+)
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+type UserDataSource struct {
+	client *mongodb.Client
+}
+
+type UserDataSourceModel struct {
+	Username   types.String `tfsdk:"username"`
+	Database   types.String `tfsdk:"database"`
+	Roles      types.Set    `tfsdk:"roles"`
+	Mechanisms types.Set    `tfsdk:"mechanisms"`
+
+	AuthenticationRestrictions types.Set    `tfsdk:"authentication_restrictions"`
+	CustomData                 types.String `tfsdk:"custom_data"`
+}
+
+func (u *UserDataSourceModel) updateState(ctx context.Context, user *mongodb.User) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	u.Username = types.StringValue(user.Username)
+	u.Database = types.StringValue(user.Database)
+
+	roles, d := user.Roles.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	if roles != nil {
+		u.Roles = *roles
+	}
+
+	mechanisms := user.Mechanisms
+	if mechanisms == nil {
+		mechanisms = []string{}
+	}
+
+	u.Mechanisms, d = types.SetValueFrom(ctx, types.StringType, mechanisms)
+	diags.Append(d...)
+
+	u.AuthenticationRestrictions, d = newAuthenticationRestrictionsSet(
+		ctx,
+		types.SetNull(types.ObjectType{AttrTypes: AuthenticationRestrictionModel{}.AttributeTypes()}),
+		user.AuthenticationRestrictions,
+	)
+	diags.Append(d...)
+
+	u.CustomData = types.StringNull()
+
+	if len(user.CustomData) > 0 {
+		customData, err := marshalExtendedJSON(user.CustomData)
+		if err != nil {
+			diags.AddError("Failed to parse custom data", err.Error())
+
+			return diags
+		}
+
+		u.CustomData = types.StringValue(customData)
+	}
+
+	return diags
+}
+
+// userDataSourceAttributes are shared by mongodb_user and the users of mongodb_users.
+func userDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"username": schema.StringAttribute{
+			MarkdownDescription: "User name",
+			Computed:            true,
+		},
+		"database": schema.StringAttribute{
+			MarkdownDescription: "Auth database name (auth source)",
+			Computed:            true,
+		},
+		"roles": shortRolesDataSourceSchema("The roles granted to the user"),
+		"mechanisms": schema.SetAttribute{
+			MarkdownDescription: "SCRAM mechanisms of the user credentials, " +
+				"empty if the server doesn't report them",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"authentication_restrictions": authenticationRestrictionsDataSourceSchema(),
+		"custom_data": schema.StringAttribute{
+			MarkdownDescription: "JSON encoded custom data document, null if the user has none",
+			Computed:            true,
+		},
+	}
+}
+
+// shortRolesDataSourceSchema describes roles referenced by name and database.
+func shortRolesDataSourceSchema(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"role": schema.StringAttribute{
+					MarkdownDescription: "Role name",
+					Computed:            true,
+				},
+				"db": schema.StringAttribute{
+					MarkdownDescription: "Role database name",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := userDataSourceAttributes()
+
+	attributes["username"] = schema.StringAttribute{
+		MarkdownDescription: "User name",
+		Required:            true,
+	}
+	attributes["database"] = schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Auth database name (auth source). "+
+			"%q is used by default", defaultDatabase),
+		Optional: true,
+		Computed: true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing MongoDB user",
+		Attributes:          attributes,
+	}
+}
+
+func (d *UserDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkDataSourceClient(ctx, d.client, req, resp) {
+		return
+	}
+
+	var config UserDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := config.Database.ValueString()
+	if database == "" {
+		database = defaultDatabase
+	}
+
+	user, err := d.client.GetUser(ctx, &mongodb.GetUserOptions{
+		Username: config.Username.ValueString(),
+		Database: database,
+	})
+	if errors.As(err, &mongodb.NotFoundError{}) {
+		resp.Diagnostics.AddError(
+			"User not found",
+			fmt.Sprintf("User %q doesn't exist in database %q", config.Username.ValueString(), database),
+		)
+
+		return
+	}
+
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error reading user", err, nil)
+
+		return
+	}
+
+	resp.Diagnostics.Append(config.updateState(ctx, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -15,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
 )
@@ -26,6 +29,21 @@ const (
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithConfigure = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+var _ resource.ResourceWithValidateConfig = &UserResource{}
+
+var userErrorAttributes = errorAttributes{
+	mongodb.RoleNotFoundCode: path.Root("roles"),
+}
+
+// userServerRequirements are user options which compatible servers don't support.
+var userServerRequirements = []serverRequirement{
+	{
+		attribute:   "authentication_restrictions",
+		unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB, mongodb.FlavorCosmosDB},
+	},
+	{attribute: "custom_data", unsupported: []mongodb.Flavor{mongodb.FlavorDocumentDB}},
+}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -41,12 +59,21 @@ type UserResourceModel struct {
 	Database   types.String `tfsdk:"database"`
 	Roles      types.Set    `tfsdk:"roles"`
 	Mechanisms types.Set    `tfsdk:"mechanisms"`
+
+	AuthenticationRestrictions types.Set    `tfsdk:"authentication_restrictions"`
+	CustomData                 types.String `tfsdk:"custom_data"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func newUserResourceModel() UserResourceModel {
 	return UserResourceModel{
 		Roles:      types.SetNull(types.ObjectType{AttrTypes: mongodb.ShortRoleAttributeTypes}),
 		Mechanisms: types.SetNull(types.StringType),
+		AuthenticationRestrictions: types.SetNull(types.ObjectType{
+			AttrTypes: AuthenticationRestrictionModel{}.AttributeTypes(),
+		}),
+		Timeouts: nullTimeouts(),
 	}
 }
 
@@ -78,13 +105,33 @@ func (u *UserResourceModel) updateState(ctx context.Context, user *mongodb.User)
 
 	u.Roles = *roles
 
-	// DocumentDB does not return mechanisms.
-	// If that's the case - keep the value same as in plan.
+	// Keep the planned mechanisms if the server doesn't return them, whatever its flavor
 	if len(user.Mechanisms) > 0 {
 		u.Mechanisms, d = types.SetValueFrom(ctx, types.StringType, user.Mechanisms)
 		diags.Append(d...)
 	}
 
+	u.AuthenticationRestrictions, d = newAuthenticationRestrictionsSet(
+		ctx,
+		u.AuthenticationRestrictions,
+		user.AuthenticationRestrictions,
+	)
+	diags.Append(d...)
+
+	// Keep null if custom data was never set
+	if len(user.CustomData) == 0 && u.CustomData.IsNull() {
+		return diags
+	}
+
+	customData, err := marshalExtendedJSON(user.CustomData)
+	if err != nil {
+		diags.AddError("Failed to parse custom data", err.Error())
+
+		return diags
+	}
+
+	u.CustomData = semanticJSONValue(u.CustomData, customData, user.CustomData)
+
 	return diags
 }
 
@@ -92,21 +139,23 @@ func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest,
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
 
-func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *UserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "MongoDB User resource",
 
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				MarkdownDescription: "The name of the new user",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The name of the new user. "+
+					"Users in %q database are named after the RFC 2253 subject of their X.509 certificate, "+
+					"e.g. `CN=client,OU=eng,O=example.com`", externalDatabase),
+				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"password": schema.StringAttribute{
 				MarkdownDescription: fmt.Sprintf("The user's password. "+
-					"Must be empty for %q database", externalDatabase),
+					"Not allowed for %q database", externalDatabase),
 				Optional:  true,
 				Sensitive: true,
 			},
@@ -153,7 +202,75 @@ func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					setplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"authentication_restrictions": authenticationRestrictionsSchema(),
+			"custom_data": schema.StringAttribute{
+				MarkdownDescription: "JSON (or Extended JSON) encoded document with any information " +
+					"to store with the user, e.g. the owning team",
+				Optional: true,
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *UserResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config UserResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.CustomData.IsNull() && !config.CustomData.IsUnknown() {
+		var customData bson.D
+
+		err := parseExtendedJSON(config.CustomData.ValueString(), &customData)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("custom_data"),
+				"Failed to parse custom data json",
+				err.Error(),
+			)
+		}
+	}
+
+	// X.509 users authenticate with a certificate
+	if config.Database.ValueString() != externalDatabase {
+		return
+	}
+
+	if !config.Username.IsUnknown() {
+		err := validateDistinguishedName(config.Username.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Invalid X.509 username",
+				fmt.Sprintf("Users in %q must be named after the RFC 2253 certificate subject, "+
+					"e.g. \"CN=client,OU=eng,O=example.com\": %s", externalDatabase, err),
+			)
+		}
+	}
+
+	if !config.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Password is not supported",
+			fmt.Sprintf("Users in %q authenticate with a certificate and can't have a password", externalDatabase),
+		)
+	}
+
+	if !config.Mechanisms.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mechanisms"),
+			"Mechanisms are not supported",
+			fmt.Sprintf("Users in %q don't have SCRAM credentials", externalDatabase),
+		)
 	}
 }
 
@@ -176,8 +293,16 @@ func (r *UserResource) Configure(_ context.Context, req resource.ConfigureReques
 	r.client = p.client
 }
 
+func (r *UserResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	checkServerRequirements(ctx, r.client, req.Plan, req.Config, userServerRequirements, &resp.Diagnostics)
+}
+
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -208,18 +333,47 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		}
 	}
 
+	// Parse authentication restrictions
+	var restrictions mongodb.AuthenticationRestrictions
+
+	resp.Diagnostics.Append(getAuthenticationRestrictions(ctx, plan.AuthenticationRestrictions, &restrictions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Parse custom data
+	var customData bson.D
+
+	if !plan.CustomData.IsNull() && !plan.CustomData.IsUnknown() {
+		err := parseExtendedJSON(plan.CustomData.ValueString(), &customData)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse custom data json", err.Error())
+
+			return
+		}
+	}
+
+	createTimeout, d := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	user, err := r.client.UpsertUser(ctx, &mongodb.User{
 		Username:   plan.Username.ValueString(),
 		Password:   plan.Password.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Roles:      roles,
 		Mechanisms: mechanisms,
+
+		AuthenticationRestrictions: restrictions,
+		CustomData:                 customData,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"failed to upsert user",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "failed to upsert user", err, userErrorAttributes)
 
 		return
 	}
@@ -234,7 +388,13 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if r.client == nil {
+		deferRead(ctx, req, resp)
+
+		return
+	}
+
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -245,16 +405,22 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, d := plan.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	user, err := r.client.GetUser(ctx, &mongodb.GetUserOptions{
 		Username: plan.Username.ValueString(),
 		Database: plan.Database.ValueString(),
 	})
 	if err != nil {
 		if !errors.As(err, &mongodb.NotFoundError{}) {
-			resp.Diagnostics.AddError(
-				"failed to get user",
-				err.Error(),
-			)
+			addClientError(ctx, &resp.Diagnostics, "failed to get user", err, userErrorAttributes)
 
 			return
 		}
@@ -274,7 +440,7 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -305,18 +471,52 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}
 
+	// Parse authentication restrictions
+	var restrictions mongodb.AuthenticationRestrictions
+
+	resp.Diagnostics.Append(getAuthenticationRestrictions(ctx, plan.AuthenticationRestrictions, &restrictions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Parse custom data
+	var customData bson.D
+
+	if !plan.CustomData.IsNull() && !plan.CustomData.IsUnknown() {
+		err := parseExtendedJSON(plan.CustomData.ValueString(), &customData)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse custom data json", err.Error())
+
+			return
+		}
+	}
+
+	updateTimeout, d := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	user, err := r.client.UpsertUser(ctx, &mongodb.User{
 		Username:   plan.Username.ValueString(),
 		Password:   plan.Password.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Roles:      roles,
 		Mechanisms: mechanisms,
+
+		AuthenticationRestrictions: restrictions,
+		CustomData:                 customData,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"failed to upsert user",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "failed to upsert user", err, userErrorAttributes)
+
+		// Record roles which were already changed, so the next plan shows only the rest
+		if errors.As(err, &mongodb.RolesChangeError{}) {
+			r.recordPartialUpdate(ctx, &plan, resp)
+		}
 
 		return
 	}
@@ -330,8 +530,37 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+func (r *UserResource) recordPartialUpdate(
+	ctx context.Context,
+	plan *UserResourceModel,
+	resp *resource.UpdateResponse,
+) {
+	// The update may have run out of its timeout, the changes are still worth recording
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultReadTimeout)
+	defer cancel()
+
+	user, err := r.client.GetUser(ctx, &mongodb.GetUserOptions{
+		Username: plan.Username.ValueString(),
+		Database: plan.Database.ValueString(),
+	})
+	if err != nil {
+		tflog.Warn(ctx, "failed to read user after partial update", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	diags := plan.updateState(ctx, user)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
@@ -342,15 +571,21 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, d := plan.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteUser(ctx, &mongodb.DeleteUserOptions{
 		Username: plan.Username.ValueString(),
 		Database: plan.Database.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"failed to delete user",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "failed to delete user", err, userErrorAttributes)
 	}
 
 	tflog.Trace(ctx, "user deleted")
@@ -362,22 +597,34 @@ func (r *UserResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	if !r.checkClient(resp.Diagnostics) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
 		return
 	}
 
-	idParts := strings.Split(req.ID, ".")
-
 	var username, database string
 
+	// Database names can't contain dots, but usernames can.
+	// X.509 usernames are distinguished names, so the database can be omitted.
 	switch {
-	case len(idParts) == 2:
-		database = idParts[0]
-		username = idParts[1]
-	case len(idParts) == 1:
-		username = idParts[0]
-		database = defaultDatabase
+	case strings.HasPrefix(req.ID, externalDatabase+"."):
+		database = externalDatabase
+		username = strings.TrimPrefix(req.ID, externalDatabase+".")
+	case isDistinguishedName(req.ID):
+		database = externalDatabase
+		username = req.ID
 	default:
+		idParts := strings.SplitN(req.ID, ".", 2)
+
+		if len(idParts) == 2 {
+			database = idParts[0]
+			username = idParts[1]
+		} else {
+			database = defaultDatabase
+			username = idParts[0]
+		}
+	}
+
+	if database == "" || username == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
 			fmt.Sprintf("Expected import identifier with format: '[<db>.]<username>'. Got: %q", req.ID),
@@ -393,10 +640,7 @@ func (r *UserResource) ImportState(
 		Database: database,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to get user",
-			err.Error(),
-		)
+		addClientError(ctx, &resp.Diagnostics, "Failed to get user", err, userErrorAttributes)
 
 		return
 	}
@@ -409,15 +653,6 @@ func (r *UserResource) ImportState(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func (r *UserResource) checkClient(diag diag.Diagnostics) bool {
-	if r.client == nil {
-		diag.AddError(
-			"MongoDB client is not configured",
-			"Expected configured MongoDB client. Please report this issue to the provider developers.",
-		)
-
-		return false
-	}
-
-	return true
+func (r *UserResource) checkClient(ctx context.Context, diags *diag.Diagnostics) bool {
+	return connectClient(ctx, r.client, diags)
 }
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ datasource.DataSource              = &UsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &UsersDataSource{}
+)
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+type UsersDataSource struct {
+	client *mongodb.Client
+}
+
+type UsersDataSourceModel struct {
+	Database types.String          `tfsdk:"database"`
+	Role     *mongodb.ShortRole    `tfsdk:"role"`
+	Users    []UserDataSourceModel `tfsdk:"users"`
+}
+
+func (d *UsersDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists MongoDB users with usersInfo, e.g. to find users with a role " +
+			"or users which aren't managed by Terraform",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Lists only users of this auth database. " +
+					"Users of all databases are listed by default",
+				Optional: true,
+			},
+			"role": schema.SingleNestedAttribute{
+				MarkdownDescription: "Lists only users granted this role directly. " +
+					"Roles inherited through other roles are not matched, e.g. a user granted a custom role " +
+					"which inherits `root` is not listed for `root`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"role": schema.StringAttribute{
+						MarkdownDescription: "Role name",
+						Required:            true,
+					},
+					"db": schema.StringAttribute{
+						MarkdownDescription: "Role database name",
+						Required:            true,
+					},
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching users, ordered by database and user name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkDataSourceClient(ctx, d.client, req, resp) {
+		return
+	}
+
+	var config UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.ListUsers(ctx, &mongodb.ListUsersOptions{
+		Database: config.Database.ValueString(),
+		Role:     config.Role,
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error listing users", err, nil)
+
+		return
+	}
+
+	slices.SortFunc(users, func(a, b mongodb.User) int {
+		return cmp.Or(cmp.Compare(a.Database, b.Database), cmp.Compare(a.Username, b.Username))
+	})
+
+	config.Users = make([]UserDataSourceModel, len(users))
+
+	for i := range users {
+		resp.Diagnostics.Append(config.Users[i].updateState(ctx, &users[i])...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
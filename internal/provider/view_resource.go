@@ -0,0 +1,365 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                   = &ViewResource{}
+	_ resource.ResourceWithConfigure      = &ViewResource{}
+	_ resource.ResourceWithImportState    = &ViewResource{}
+	_ resource.ResourceWithValidateConfig = &ViewResource{}
+)
+
+var viewErrorAttributes = errorAttributes{
+	mongodb.NamespaceExistsCode: path.Root("name"),
+}
+
+func NewViewResource() resource.Resource {
+	return &ViewResource{}
+}
+
+type ViewResource struct {
+	client *mongodb.Client
+}
+
+type ViewResourceModel struct {
+	Database  types.String `tfsdk:"database"`
+	Name      types.String `tfsdk:"name"`
+	ViewOn    types.String `tfsdk:"view_on"`
+	Pipeline  types.String `tfsdk:"pipeline"`
+	Collation types.Object `tfsdk:"collation"`
+}
+
+func (v *ViewResourceModel) GetView(ctx context.Context, view *mongodb.View) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	view.Database = v.Database.ValueString()
+	view.Name = v.Name.ValueString()
+	view.Options.ViewOn = v.ViewOn.ValueString()
+
+	err := parseExtendedJSON(v.Pipeline.ValueString(), &view.Options.Pipeline)
+	if err != nil {
+		diags.AddError("Failed to parse pipeline json", err.Error())
+
+		return diags
+	}
+
+	if !v.Collation.IsNull() && !v.Collation.IsUnknown() {
+		diags.Append(getCollation(ctx, v.Collation, &view.Options.Collation)...)
+	}
+
+	return diags
+}
+
+func (v *ViewResourceModel) updateState(ctx context.Context, view *mongodb.View) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	v.Database = types.StringValue(view.Database)
+	v.Name = types.StringValue(view.Name)
+	v.ViewOn = types.StringValue(view.Options.ViewOn)
+
+	// Parse pipeline
+	pipeline, err := marshalExtendedJSONArray(view.Options.Pipeline)
+	if err != nil {
+		diags.AddError("Failed to parse pipeline", err.Error())
+
+		return diags
+	}
+
+	v.Pipeline = semanticJSONValue(v.Pipeline, pipeline, view.Options.Pipeline)
+
+	// Parse collation
+	collation, d := newCollationObject(ctx, view.Options.Collation)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	v.Collation = collation
+
+	return diags
+}
+
+func (r *ViewResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_view"
+}
+
+func (r *ViewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages MongoDB read-only views. " +
+			"Privileges on a view are granted with mongodb_role using the view name as the collection",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "View name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"view_on": schema.StringAttribute{
+				Description: "Name of the source collection or view. Changed in place",
+				Required:    true,
+			},
+			"pipeline": schema.StringAttribute{
+				Description: "JSON (or Extended JSON) encoded array of aggregation pipeline stages. Changed in place",
+				Required:    true,
+			},
+			"collation": collationSchema(),
+		},
+	}
+}
+
+func (r *ViewResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config ViewResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Pipeline.IsNull() || config.Pipeline.IsUnknown() {
+		return
+	}
+
+	var pipeline bson.A
+
+	err := parseExtendedJSON(config.Pipeline.ValueString(), &pipeline)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pipeline"),
+			"Failed to parse pipeline json",
+			err.Error(),
+		)
+	}
+}
+
+func (r *ViewResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *ViewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ViewResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	view := &mongodb.View{}
+
+	resp.Diagnostics.Append(plan.GetView(ctx, view)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dbView, err := r.client.CreateView(ctx, view)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error creating MongoDB view", err, viewErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, dbView)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "View created")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ViewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		deferRead(ctx, req, resp)
+
+		return
+	}
+
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ViewResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	view, err := r.client.GetView(ctx, &mongodb.GetViewOptions{
+		Name:     plan.Name.ValueString(),
+		Database: plan.Database.ValueString(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.NotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		addClientError(ctx, &resp.Diagnostics, "Error reading MongoDB view", err, viewErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, view)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ViewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ViewResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	view := &mongodb.View{}
+
+	resp.Diagnostics.Append(plan.GetView(ctx, view)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dbView, err := r.client.ModifyView(ctx, view)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error updating MongoDB view", err, viewErrorAttributes)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, dbView)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "View updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ViewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	var plan ViewResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DropView(ctx, &mongodb.GetViewOptions{
+		Name:     plan.Name.ValueString(),
+		Database: plan.Database.ValueString(),
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, "Error deleting MongoDB view", err, viewErrorAttributes)
+
+		return
+	}
+
+	tflog.Trace(ctx, "View deleted")
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ViewResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(ctx, &resp.Diagnostics) {
+		return
+	}
+
+	// View names can contain dots, database names can't
+	idParts := strings.SplitN(req.ID, ".", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: database.view",
+		)
+
+		return
+	}
+
+	var plan ViewResourceModel
+
+	view, err := r.client.GetView(ctx, &mongodb.GetViewOptions{
+		Name:     idParts[1],
+		Database: idParts[0],
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing view",
+			fmt.Sprintf("Failed to read view %s: %s", req.ID, err),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, view)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ViewResource) checkClient(ctx context.Context, diags *diag.Diagnostics) bool {
+	return connectClient(ctx, r.client, diags)
+}